@@ -2,30 +2,144 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/alecthomas/kong"
 	"github.com/timtatt/sift/internal/sift"
 )
 
 type CLI struct {
-	Debug          bool `name:"debug" short:"d" help:"enable debug view"`
-	RawLogs        bool `name:"raw" short:"r" help:"disable prettified logs"`
-	NonInteractive bool `name:"non-interactive" short:"n" help:"disable interactive mode"`
-	Version        bool `name:"version" short:"v" help:"print version"`
+	Debug            bool             `name:"debug" short:"d" help:"enable debug view"`
+	RawLogs          bool             `name:"raw" short:"r" help:"disable prettified logs"`
+	NonInteractive   bool             `name:"non-interactive" short:"n" help:"disable interactive mode"`
+	Theme            string           `name:"theme" help:"color theme (default, high-contrast, no-color or a theme defined in the config)"`
+	ASCII            bool             `name:"ascii" help:"only use ascii characters for icons"`
+	HangThreshold    time.Duration    `name:"hang-threshold" default:"2m" help:"flag tests running for longer than this as possibly hung, 0 to disable"`
+	Bell             bool             `name:"bell" help:"ring the terminal bell when a test may be hung"`
+	NoHistory        bool             `name:"no-history" help:"don't record the run in the history"`
+	HistoryRetention int              `name:"history-retention" default:"100" help:"number of runs to keep in the history, 0 to keep every run"`
+	Version          kong.VersionFlag `name:"version" short:"v" help:"print version"`
+
+	Run       RunCmd       `cmd:"" default:"withargs" hidden:"" help:"view the output of go test -json from stdin, or run go test with the args"`
+	BenchDiff BenchDiffCmd `cmd:"" name:"bench-diff" help:"compare benchmarks between two runs recorded with go test -json"`
+}
+
+// ConfigPaths are the locations of the optional json config file which provides
+// defaults for any of the cli flags. eg. {"theme": "high-contrast"}
+func ConfigPaths() []string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil
+	}
+
+	return []string{filepath.Join(configDir, "sift", "config.json")}
+}
+
+// theme resolves the color theme, respecting the NO_COLOR convention https://no-color.org.
+// a theme chosen with the flag or in the config overrides NO_COLOR
+func (c *CLI) theme() string {
+	if c.Theme != "" {
+		return c.Theme
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return "no-color"
+	}
+
+	return "default"
+}
+
+// config is the part of the config file which isn't a default for a flag
+type config struct {
+	Themes map[string]sift.ThemeConfig `json:"themes"`
+}
+
+// setupThemes adds the themes defined in the config. a theme chosen with the
+// flag or in the config is rendered in color even when NO_COLOR is set
+func (c *CLI) setupThemes() error {
+	if err := registerThemes(ConfigPaths()); err != nil {
+		return err
+	}
+
+	if c.Theme != "" && c.Theme != "no-color" && os.Getenv("NO_COLOR") != "" {
+		sift.IgnoreNoColor()
+	}
+
+	return nil
+}
+
+// registerThemes adds the themes defined in the config files
+func registerThemes(paths []string) error {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+
+		var cfg config
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		if err := sift.RegisterThemes(cfg.Themes); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+type RunCmd struct {
+	GoTestArgs []string `arg:"" optional:"" passthrough:"" name:"packages" help:"packages and flags to run go test -json with, instead of reading its output from stdin"`
 }
 
-func (c *CLI) Run() error {
+func (r *RunCmd) Run(cli *CLI) error {
 	ctx := context.Background()
 
-	if c.Version {
-		fmt.Print(sift.Version)
-		os.Exit(0)
+	if err := cli.setupThemes(); err != nil {
+		return err
 	}
 
 	return sift.Run(ctx, sift.SiftOptions{
-		Debug:          c.Debug,
-		NonInteractive: c.NonInteractive,
-		PrettifyLogs:   !c.RawLogs,
+		Debug:            cli.Debug,
+		NonInteractive:   cli.NonInteractive,
+		PrettifyLogs:     !cli.RawLogs,
+		Theme:            cli.theme(),
+		ASCII:            cli.ASCII,
+		HangThreshold:    cli.HangThreshold,
+		Bell:             cli.Bell,
+		NoHistory:        cli.NoHistory,
+		HistoryRetention: cli.HistoryRetention,
+		GoTestArgs:       r.GoTestArgs,
+	})
+}
+
+type BenchDiffCmd struct {
+	Old    string `arg:"" type:"existingfile" help:"go test -json output of the baseline run"`
+	New    string `arg:"" type:"existingfile" help:"go test -json output of the run to compare"`
+	Format string `name:"format" short:"f" default:"tui" enum:"tui,text,markdown" help:"output format (${enum})"`
+}
+
+func (b *BenchDiffCmd) Run(cli *CLI) error {
+	ctx := context.Background()
+
+	if err := cli.setupThemes(); err != nil {
+		return err
+	}
+
+	return sift.RunBenchDiff(ctx, sift.BenchDiffOptions{
+		Old:    b.Old,
+		New:    b.New,
+		Format: b.Format,
+		Theme:  cli.theme(),
+		ASCII:  cli.ASCII,
 	})
 }
@@ -0,0 +1,27 @@
+package history
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// AddGitInfo records the commit and branch the tests were run on. they are
+// left empty outside of a git repo
+func (r *Run) AddGitInfo(ctx context.Context) {
+	r.Commit = git(ctx, "rev-parse", "HEAD")
+
+	// a detached HEAD has no branch
+	if branch := git(ctx, "rev-parse", "--abbrev-ref", "HEAD"); branch != "HEAD" {
+		r.Branch = branch
+	}
+}
+
+func git(ctx context.Context, args ...string) string {
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
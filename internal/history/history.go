@@ -0,0 +1,263 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/timtatt/sift/internal/tests"
+)
+
+// Run is the results of a single run of the tests
+type Run struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	// Project is the directory of the go module the tests were run in, see
+	// FindProject
+	Project string `json:"project"`
+
+	// Commit and Branch are empty when the tests weren't run in a git repo
+	Commit string `json:"commit,omitempty"`
+	Branch string `json:"branch,omitempty"`
+
+	// Args are what sift ran go test with, empty when reading from stdin
+	Args []string `json:"args,omitempty"`
+
+	Tests []TestResult `json:"tests"`
+}
+
+type TestResult struct {
+	Package string        `json:"package"`
+	Test    string        `json:"test,omitempty"` // empty for a package which failed to build
+	Status  string        `json:"status"`
+	Elapsed time.Duration `json:"elapsed"`
+
+	// Fingerprint identifies why the test failed, see TestManager.GetFailureFingerprint
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+func (r TestResult) Ref() tests.TestReference {
+	return tests.TestReference{Package: r.Package, Test: r.Test}
+}
+
+// NewRun collects the results of the tests which finished
+func NewRun(tm *tests.TestManager, start, end time.Time) Run {
+	run := Run{
+		StartTime: start,
+		EndTime:   end,
+	}
+
+	for _, test := range tm.GetTests {
+		if !test.Status.Done() {
+			continue
+		}
+
+		run.Tests = append(run.Tests, TestResult{
+			Package:     test.Ref.Package,
+			Test:        test.Ref.Test,
+			Status:      test.Status.String(),
+			Elapsed:     test.Elapsed,
+			Fingerprint: tm.GetFailureFingerprint(test),
+		})
+	}
+
+	return run
+}
+
+// Store keeps each run of a project as a json file, removing the oldest runs
+// of the project beyond the retention. the runs of each project are kept
+// apart, so projects don't share timings or evict each other's runs
+type Store struct {
+	dir     string
+	project string
+
+	// retention is how many runs are kept, 0 keeps every run
+	retention int
+}
+
+func NewStore(dir, project string, retention int) *Store {
+	return &Store{
+		dir:       dir,
+		project:   project,
+		retention: retention,
+	}
+}
+
+// FindProject finds the root of the go module containing the directory, which
+// the runs are grouped by. the directory itself is used outside of a module
+func FindProject(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+			return current
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// projectKey names the directory of the project's runs, with the name of the
+// project to make it recognisable and a hash of its path to keep it unique
+func projectKey(project string) string {
+	sum := sha256.Sum256([]byte(project))
+	return filepath.Base(project) + "-" + hex.EncodeToString(sum[:6])
+}
+
+// DefaultDir is where the history is stored, following the XDG base directory
+// spec https://specifications.freedesktop.org/basedir-spec/latest
+func DefaultDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "sift"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the history directory: %w", err)
+	}
+
+	return filepath.Join(home, ".local", "share", "sift"), nil
+}
+
+func (s *Store) runsDir() string {
+	return filepath.Join(s.dir, "runs", projectKey(s.project))
+}
+
+// runFileName sorts the runs by when they started
+func runFileName(run Run) string {
+	return run.StartTime.UTC().Format("20060102T150405.000000000Z") + ".json"
+}
+
+func (s *Store) Save(run Run) error {
+	run.Project = s.project
+
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to encode run: %w", err)
+	}
+
+	if err := os.MkdirAll(s.runsDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.runsDir(), runFileName(run)), data, 0644); err != nil {
+		return fmt.Errorf("failed to write run: %w", err)
+	}
+
+	return s.prune()
+}
+
+// runFiles lists the files of the runs, the newest first
+func (s *Store) runFiles() ([]string, error) {
+	entries, err := os.ReadDir(s.runsDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			files = append(files, entry.Name())
+		}
+	}
+
+	slices.Sort(files)
+	slices.Reverse(files)
+
+	return files, nil
+}
+
+// prune removes the oldest runs beyond the retention
+func (s *Store) prune() error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	files, err := s.runFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files[min(len(files), s.retention):] {
+		if err := os.Remove(filepath.Join(s.runsDir(), file)); err != nil {
+			return fmt.Errorf("failed to remove run: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Runs reads the runs of the project in the history, the newest first. runs
+// which can't be read are skipped, rather than losing the rest of the history
+func (s *Store) Runs() ([]Run, error) {
+	files, err := s.runFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readRuns(files), nil
+}
+
+func (s *Store) readRuns(files []string) []Run {
+	var runs []Run
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(s.runsDir(), file))
+		if err != nil {
+			continue
+		}
+
+		var run Run
+		if err := json.Unmarshal(data, &run); err != nil {
+			continue
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs
+}
+
+// durationRuns is how many of the latest runs are read for the durations, so
+// a long history doesn't slow down starting up
+const durationRuns = 10
+
+// Durations finds how long each top level test took the last time it ran to
+// completion, within the latest runs. tests which were skipped, aborted or
+// timed out are left out as their duration doesn't reflect how long they
+// usually take
+func (s *Store) Durations() (tests.Durations, error) {
+	files, err := s.runFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make(tests.Durations)
+	for _, run := range s.readRuns(files[:min(len(files), durationRuns)]) {
+		for _, result := range run.Tests {
+			if _, isSubtest := result.Ref().Parent(); isSubtest || result.Test == "" {
+				continue
+			}
+
+			if result.Status != tests.StatusPassed.String() && result.Status != tests.StatusFailed.String() {
+				continue
+			}
+
+			durations.SetDefault(result.Ref(), result.Elapsed)
+		}
+	}
+
+	return durations, nil
+}
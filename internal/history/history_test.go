@@ -0,0 +1,165 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timtatt/sift/internal/tests"
+)
+
+func newRun(t *testing.T, start time.Time, lines ...tests.TestOutputLine) Run {
+	t.Helper()
+
+	tm := tests.NewTestManager(tests.TestManagerOpts{})
+	for _, line := range lines {
+		tm.AddTestOutput(line)
+	}
+
+	return NewRun(tm, start, start.Add(time.Minute))
+}
+
+func TestNewRun(t *testing.T) {
+	run := newRun(t, time.Now(),
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestA"},
+		tests.TestOutputLine{Action: "output", Package: "pkg", Test: "TestA", Output: "    foo_test.go:10: boom\n", OutputType: "error"},
+		tests.TestOutputLine{Action: "fail", Package: "pkg", Test: "TestA", Elapsed: 2},
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestB"},
+	)
+
+	// TestB is left out as it didn't finish
+	require.Len(t, run.Tests, 1)
+
+	result := run.Tests[0]
+	assert.Equal(t, "TestA", result.Test)
+	assert.Equal(t, "failed", result.Status)
+	assert.Equal(t, 2*time.Second, result.Elapsed)
+	assert.NotEmpty(t, result.Fingerprint)
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, "/src/project", 2)
+	other := NewStore(dir, "/src/other", 2)
+
+	runs, err := store.Runs()
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, other.Save(newRun(t, start.Add(-time.Hour),
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestA"},
+		tests.TestOutputLine{Action: "pass", Package: "pkg", Test: "TestA"},
+	)))
+
+	for i := range 3 {
+		run := newRun(t, start.Add(time.Duration(i)*time.Hour),
+			tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestA"},
+			tests.TestOutputLine{Action: "pass", Package: "pkg", Test: "TestA", Elapsed: float64(i + 1)},
+		)
+		require.NoError(t, store.Save(run))
+	}
+
+	// the oldest run of the project is removed beyond the retention
+	files, err := os.ReadDir(filepath.Join(dir, "runs", projectKey("/src/project")))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	runs, err = store.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, start.Add(2*time.Hour), runs[0].StartTime)
+	assert.Equal(t, start.Add(time.Hour), runs[1].StartTime)
+	assert.Equal(t, "/src/project", runs[0].Project)
+
+	// the runs of other projects are kept apart
+	runs, err = other.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "/src/other", runs[0].Project)
+}
+
+func TestFindProject(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "pkg", "nested")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+
+	// outside of a module the directory itself is the project
+	assert.Equal(t, nested, FindProject(nested))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0o644))
+	assert.Equal(t, dir, FindProject(nested))
+	assert.Equal(t, dir, FindProject(dir))
+}
+
+func TestStore_Durations(t *testing.T) {
+	store := NewStore(t.TempDir(), "/src/project", 0)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(newRun(t, start,
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestA"},
+		tests.TestOutputLine{Action: "pass", Package: "pkg", Test: "TestA", Elapsed: 1},
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestB"},
+		tests.TestOutputLine{Action: "pass", Package: "pkg", Test: "TestB", Elapsed: 4},
+	)))
+	require.NoError(t, store.Save(newRun(t, start.Add(time.Hour),
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestA"},
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestA/one"},
+		tests.TestOutputLine{Action: "pass", Package: "pkg", Test: "TestA/one", Elapsed: 1},
+		tests.TestOutputLine{Action: "fail", Package: "pkg", Test: "TestA", Elapsed: 2},
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestB"},
+		tests.TestOutputLine{Action: "skip", Package: "pkg", Test: "TestB"},
+	)))
+
+	durations, err := store.Durations()
+	require.NoError(t, err)
+
+	// the latest duration of each test which ran to completion is used
+	assert.Equal(t, tests.Durations{"pkg": {
+		"TestA": 2 * time.Second,
+		"TestB": 4 * time.Second,
+	}}, durations)
+}
+
+func TestStore_Durations_LatestRuns(t *testing.T) {
+	store := NewStore(t.TempDir(), "/src/project", 0)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(newRun(t, start,
+		tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestOld"},
+		tests.TestOutputLine{Action: "pass", Package: "pkg", Test: "TestOld", Elapsed: 1},
+	)))
+
+	for i := range durationRuns {
+		require.NoError(t, store.Save(newRun(t, start.Add(time.Duration(i+1)*time.Hour),
+			tests.TestOutputLine{Action: "run", Package: "pkg", Test: "TestA"},
+			tests.TestOutputLine{Action: "pass", Package: "pkg", Test: "TestA", Elapsed: 1},
+		)))
+	}
+
+	durations, err := store.Durations()
+	require.NoError(t, err)
+
+	// runs older than the latest are not read
+	assert.Equal(t, tests.Durations{"pkg": {
+		"TestA": time.Second,
+	}}, durations)
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+
+	dir, err := DefaultDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/data/sift", dir)
+
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/user")
+
+	dir, err = DefaultDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.local/share/sift", dir)
+}
@@ -0,0 +1,67 @@
+package sift
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/pkg/logparse"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// parseAssertionFailure checks if the log is a failed testify assertion
+func parseAssertionFailure(log logparse.LogEntry) (outputparse.AssertionFailure, bool) {
+	if len(log.Continuation) == 0 {
+		return outputparse.AssertionFailure{}, false
+	}
+
+	return outputparse.ParseAssertionFailure(log.Lines())
+}
+
+// getDiffLineStyle colors a line of a unified diff by whether it was removed or added
+func getDiffLineStyle(line string, baseStyle lipgloss.Style) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(line, "@@"):
+		return styleDiffHunk.Inherit(baseStyle)
+	case strings.HasPrefix(line, "-"):
+		return styleDiffRemoved.Inherit(baseStyle)
+	case strings.HasPrefix(line, "+"):
+		return styleDiffAdded.Inherit(baseStyle)
+	default:
+		return styleSecondary.Inherit(baseStyle)
+	}
+}
+
+// assertionView renders a failed assertion with the expected value in red,
+// the actual value in green and the diff between them colored line by line
+func assertionView(failure outputparse.AssertionFailure, baseStyle lipgloss.Style) string {
+	var lines []string
+
+	header := failure.Message
+	if failure.File != "" {
+		header = fmt.Sprintf("%s:%d: %s", filepath.Base(failure.File), failure.Line, header)
+	}
+	lines = append(lines, baseStyle.Render(header))
+
+	for _, detail := range failure.Details {
+		lines = append(lines, baseStyle.Render("  "+detail))
+	}
+
+	if failure.Expected != "" || failure.Actual != "" {
+		lines = append(lines,
+			styleDiffRemoved.Inherit(baseStyle).Render("  expected: "+failure.Expected),
+			styleDiffAdded.Inherit(baseStyle).Render("  actual  : "+failure.Actual),
+		)
+	}
+
+	for _, line := range failure.Diff {
+		lines = append(lines, getDiffLineStyle(line, baseStyle).Render("  "+line))
+	}
+
+	if failure.Messages != "" {
+		lines = append(lines, baseStyle.Render("  messages: "+failure.Messages))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,167 @@
+package sift
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/helpview"
+)
+
+type BenchDiffOptions struct {
+	Old    string
+	New    string
+	Format string // tui, text or markdown
+	Theme  string
+	ASCII  bool
+}
+
+// loadBenchmarks reads the benchmark results from a file recorded with `go test -json`
+func loadBenchmarks(path string) ([]tests.BenchmarkResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	testManager := tests.NewTestManager(tests.TestManagerOpts{})
+	if err := scanTestOutput(f, testManager); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return testManager.GetBenchmarks(), nil
+}
+
+// RunBenchDiff compares the benchmarks between two recorded runs
+func RunBenchDiff(ctx context.Context, opts BenchDiffOptions) error {
+	old, err := loadBenchmarks(opts.Old)
+	if err != nil {
+		return err
+	}
+
+	new, err := loadBenchmarks(opts.New)
+	if err != nil {
+		return err
+	}
+
+	diffs := tests.DiffBenchmarks(old, new)
+
+	switch opts.Format {
+	case "text":
+		fmt.Print(benchDiffText(diffs))
+		return nil
+	case "markdown":
+		fmt.Print(benchDiffMarkdown(diffs))
+		return nil
+	}
+
+	if opts.ASCII {
+		useASCII()
+	}
+
+	if err := applyTheme(opts.Theme); err != nil {
+		return err
+	}
+
+	p := tea.NewProgram(newBenchDiffModel(diffs), tea.WithContext(ctx), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type benchDiffKeyMap struct{}
+
+func (benchDiffKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keys.Help, keys.Quit}
+}
+
+func (benchDiffKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{keys.Up, keys.Down},
+		{keys.viewport.HalfPageUp, keys.viewport.HalfPageDown},
+		{keys.Help, keys.Quit},
+	}
+}
+
+type benchDiffModel struct {
+	diffs []tests.BenchmarkDiff
+
+	ready      bool
+	viewport   viewport.Model
+	help       *helpview.WrappingHelpView
+	windowSize tea.WindowSizeMsg
+}
+
+func newBenchDiffModel(diffs []tests.BenchmarkDiff) *benchDiffModel {
+	help := helpview.New()
+	help.ShortSeparator = glyphs.HelpSeparator
+	help.Ellipsis = glyphs.Ellipsis
+
+	return &benchDiffModel{
+		diffs: diffs,
+		help:  help,
+	}
+}
+
+func (m *benchDiffModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *benchDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		m.help.Width = msg.Width
+		m.help.ColumnWidth = 20
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height)
+			m.viewport.KeyMap = keys.viewport
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+		}
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Up):
+			m.viewport.ScrollUp(1)
+		case key.Matches(msg, keys.Down):
+			m.viewport.ScrollDown(1)
+		case key.Matches(msg, keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case key.Matches(msg, keys.Quit):
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+
+	return m, cmd
+}
+
+func (m *benchDiffModel) View() string {
+	if !m.ready {
+		return ""
+	}
+
+	header := styleHeader.Render(glyphs.Logo+" sift bench-diff") + "\n\n"
+
+	content := benchDiffView(m.diffs)
+	m.viewport.SetContent(content)
+
+	footer := "\n" + benchDiffSummary(m.diffs)
+	footer += "\n" + lipgloss.NewStyle().PaddingTop(1).Render(m.help.View(benchDiffKeyMap{}))
+
+	// account for the padding of the body
+	maxContentHeight := m.windowSize.Height - lipgloss.Height(footer) - lipgloss.Height(header) - 2
+	m.viewport.Height = max(0, min(lipgloss.Height(content), maxContentHeight))
+
+	return styleBody.Render(header + m.viewport.View() + footer)
+}
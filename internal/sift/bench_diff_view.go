@@ -0,0 +1,202 @@
+package sift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/benchdiff"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+var benchDiffHeader = []string{"Benchmark", "Unit", "Old", "New", "Delta"}
+
+// formatDelta renders the change with a confidence indicator similar to benchstat.
+// Changes which aren't statistically significant are shown as ~
+func formatDelta(c benchdiff.Comparison) string {
+	confidence := fmt.Sprintf("(p=%.3f n=%d+%d)", c.PValue, len(c.Old), len(c.New))
+
+	if !c.Significant() {
+		return "~ " + confidence
+	}
+
+	return fmt.Sprintf("%+.2f%% %s", c.Delta, confidence)
+}
+
+// benchDiffPackages splits the diffs into groups of the same package
+func benchDiffPackages(diffs []tests.BenchmarkDiff) [][]tests.BenchmarkDiff {
+	var packages [][]tests.BenchmarkDiff
+
+	for i := 0; i < len(diffs); {
+		j := i
+		for j < len(diffs) && diffs[j].Ref.Package == diffs[i].Ref.Package {
+			j++
+		}
+
+		packages = append(packages, diffs[i:j])
+		i = j
+	}
+
+	return packages
+}
+
+// benchDiffRows builds a row for each metric of each benchmark, along with the
+// comparison the row was built from
+func benchDiffRows(diffs []tests.BenchmarkDiff) ([][]string, []benchdiff.Comparison) {
+	var (
+		rows        [][]string
+		comparisons []benchdiff.Comparison
+	)
+
+	for _, diff := range diffs {
+		for i, c := range diff.Metrics {
+			name := ""
+			if i == 0 {
+				name = strings.TrimPrefix(diff.Ref.Test, "Benchmark")
+			}
+
+			rows = append(rows, []string{
+				name,
+				c.Unit,
+				formatMetric(c.OldMean),
+				formatMetric(c.NewMean),
+				formatDelta(c),
+			})
+			comparisons = append(comparisons, c)
+		}
+	}
+
+	return rows, comparisons
+}
+
+func getDeltaStyle(c benchdiff.Comparison) lipgloss.Style {
+	switch {
+	case c.Regression():
+		return styleCross
+	case c.Improvement():
+		return styleTick
+	default:
+		return styleSecondary
+	}
+}
+
+func benchDiffView(diffs []tests.BenchmarkDiff) string {
+	if len(diffs) == 0 {
+		return styleSecondary.Render("No benchmarks were found in both runs")
+	}
+
+	vb := viewbuilder.New()
+
+	for i, pkgDiffs := range benchDiffPackages(diffs) {
+		if i > 0 {
+			vb.AddLine()
+		}
+
+		vb.Add(styleSecondary.Render(pkgDiffs[0].Ref.Package))
+		vb.AddLine()
+
+		rows, comparisons := benchDiffRows(pkgDiffs)
+		table := alignTable(append([][]string{benchDiffHeader}, rows...), 2)
+
+		vb.Add(styleSecondary.Bold(true).Render("  " + strings.Join(table[0], "  ")))
+		vb.AddLine()
+
+		for rowIdx, cells := range table[1:] {
+			c := comparisons[rowIdx]
+
+			// the delta is the last column, which is the only one styled
+			delta := getDeltaStyle(c).Render(cells[len(cells)-1])
+			vb.Add("  " + strings.Join(append(cells[:len(cells)-1], delta), "  "))
+			vb.AddLine()
+		}
+	}
+
+	return vb.String()
+}
+
+// countBenchDiffChanges counts the benchmarks with a regression in any metric,
+// and the benchmarks which only improved
+func countBenchDiffChanges(diffs []tests.BenchmarkDiff) (int, int) {
+	regressions, improvements := 0, 0
+	for _, diff := range diffs {
+		regressed, improved := false, false
+		for _, c := range diff.Metrics {
+			regressed = regressed || c.Regression()
+			improved = improved || c.Improvement()
+		}
+
+		if regressed {
+			regressions++
+		} else if improved {
+			improvements++
+		}
+	}
+
+	return regressions, improvements
+}
+
+func benchDiffSummary(diffs []tests.BenchmarkDiff) string {
+	regressions, improvements := countBenchDiffChanges(diffs)
+
+	return fmt.Sprintf(
+		"%s %s %s",
+		styleSecondary.Render(fmt.Sprintf("Benchmarks: %d", len(diffs))),
+		styleCross.Render(fmt.Sprintf("%d regressed", regressions)),
+		styleTick.Render(fmt.Sprintf("%d improved", improvements)),
+	)
+}
+
+// benchDiffText renders the diff as plain text without any styling
+func benchDiffText(diffs []tests.BenchmarkDiff) string {
+	var sb strings.Builder
+
+	for i, pkgDiffs := range benchDiffPackages(diffs) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString(pkgDiffs[0].Ref.Package + "\n")
+
+		rows, _ := benchDiffRows(pkgDiffs)
+		for _, cells := range alignTable(append([][]string{benchDiffHeader}, rows...), 2) {
+			sb.WriteString(strings.TrimRight("  "+strings.Join(cells, "  "), " ") + "\n")
+		}
+	}
+
+	regressions, improvements := countBenchDiffChanges(diffs)
+	if len(diffs) > 0 {
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Benchmarks: %d, %d regressed, %d improved\n", len(diffs), regressions, improvements)
+
+	return sb.String()
+}
+
+// benchDiffMarkdown renders the diff as markdown tables for pull request comments.
+// Regressions are shown in bold
+func benchDiffMarkdown(diffs []tests.BenchmarkDiff) string {
+	var sb strings.Builder
+
+	for _, pkgDiffs := range benchDiffPackages(diffs) {
+		fmt.Fprintf(&sb, "#### `%s`\n\n", pkgDiffs[0].Ref.Package)
+		sb.WriteString("| " + strings.Join(benchDiffHeader, " | ") + " |\n")
+		sb.WriteString("| --- | --- | ---: | ---: | --- |\n")
+
+		rows, comparisons := benchDiffRows(pkgDiffs)
+		for i, cells := range rows {
+			if comparisons[i].Regression() {
+				cells[len(cells)-1] = "**" + cells[len(cells)-1] + "**"
+			}
+
+			sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+
+		sb.WriteString("\n")
+	}
+
+	regressions, improvements := countBenchDiffChanges(diffs)
+	fmt.Fprintf(&sb, "Benchmarks: %d, %d regressed, %d improved\n", len(diffs), regressions, improvements)
+
+	return sb.String()
+}
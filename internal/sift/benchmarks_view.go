@@ -0,0 +1,192 @@
+package sift
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/outputparse"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+type benchmarkSort int
+
+const (
+	benchmarkSortName benchmarkSort = iota
+	benchmarkSortTime
+	benchmarkSortBytes
+	benchmarkSortAllocs
+	benchmarkSortCount
+)
+
+// unit is the metric the benchmarks are sorted by, empty when sorting by name
+func (s benchmarkSort) unit() string {
+	switch s {
+	case benchmarkSortTime:
+		return outputparse.UnitNsPerOp
+	case benchmarkSortBytes:
+		return outputparse.UnitBytesPerOp
+	case benchmarkSortAllocs:
+		return outputparse.UnitAllocsPerOp
+	default:
+		return ""
+	}
+}
+
+var standardBenchmarkUnits = []string{
+	outputparse.UnitNsPerOp,
+	outputparse.UnitBytesPerOp,
+	outputparse.UnitAllocsPerOp,
+}
+
+// sortBenchmarks sorts by name, or by the slowest/largest first when sorting by a metric
+func sortBenchmarks(results []tests.BenchmarkResult, sortBy benchmarkSort) {
+	slices.SortStableFunc(results, func(a, b tests.BenchmarkResult) int {
+		if c := cmp.Compare(a.Ref.Package, b.Ref.Package); c != 0 {
+			return c
+		}
+
+		if unit := sortBy.unit(); unit != "" {
+			av, aok := a.Mean(unit)
+			bv, bok := b.Mean(unit)
+
+			switch {
+			case aok && !bok:
+				return -1
+			case !aok && bok:
+				return 1
+			case av != bv:
+				return cmp.Compare(bv, av)
+			}
+		}
+
+		return cmp.Compare(a.Ref.Test, b.Ref.Test)
+	})
+}
+
+func formatMetric(v float64) string {
+	decimals := 2
+	if v >= 100 || v == float64(int64(v)) {
+		decimals = 0
+	} else if v >= 10 {
+		decimals = 1
+	}
+
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+// alignTable pads each cell to the width of its column. The first 'leftCols'
+// columns are left aligned and the rest are right aligned
+func alignTable(rows [][]string, leftCols int) [][]string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+
+	aligned := make([][]string, len(rows))
+	for rowIdx, row := range rows {
+		aligned[rowIdx] = make([]string, len(row))
+		for i, cell := range row {
+			if i < leftCols {
+				aligned[rowIdx][i] = fmt.Sprintf("%-*s", widths[i], cell)
+			} else {
+				aligned[rowIdx][i] = fmt.Sprintf("%*s", widths[i], cell)
+			}
+		}
+	}
+
+	return aligned
+}
+
+// benchmarkTable renders the benchmarks of a single package
+func (m *siftModel) benchmarkTable(results []tests.BenchmarkResult) string {
+	units := slices.Clone(standardBenchmarkUnits)
+	for _, result := range results {
+		for _, unit := range result.Units() {
+			if !slices.Contains(units, unit) {
+				units = append(units, unit)
+			}
+		}
+	}
+
+	header := append([]string{"Benchmark", "n"}, units...)
+	for i, unit := range units {
+		if unit == m.benchmarkSort.unit() {
+			header[i+2] += " " + glyphs.SortDesc
+		}
+	}
+	if m.benchmarkSort == benchmarkSortName {
+		header[0] += " " + glyphs.SortAsc
+	}
+
+	rows := [][]string{header}
+	for _, result := range results {
+		row := []string{
+			strings.TrimPrefix(result.Ref.Test, "Benchmark"),
+			strconv.Itoa(len(result.Samples)),
+		}
+
+		for _, unit := range units {
+			if mean, ok := result.Mean(unit); ok {
+				row = append(row, formatMetric(mean))
+			} else {
+				row = append(row, "-")
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	vb := viewbuilder.New()
+	for rowIdx, cells := range alignTable(rows, 1) {
+		line := "  " + strings.Join(cells, "  ")
+		if rowIdx == 0 {
+			line = styleSecondary.Bold(true).Render(line)
+		}
+
+		vb.Add(line)
+		vb.AddLine()
+	}
+
+	return vb.String()
+}
+
+func (m *siftModel) benchmarksView() string {
+	results := m.testManager.GetBenchmarks()
+	if len(results) == 0 {
+		return styleSecondary.Render("No benchmark results. Run the tests with `-bench .` to include benchmarks")
+	}
+
+	sortBenchmarks(results, m.benchmarkSort)
+
+	vb := viewbuilder.New()
+
+	for i := 0; i < len(results); {
+		pkg := results[i].Ref.Package
+
+		j := i
+		for j < len(results) && results[j].Ref.Package == pkg {
+			j++
+		}
+
+		if i > 0 {
+			vb.AddLine()
+		}
+
+		vb.Add(styleSecondary.Render(pkg))
+		vb.AddLine()
+		vb.Add(m.benchmarkTable(results[i:j]))
+
+		i = j
+	}
+
+	return vb.String()
+}
@@ -0,0 +1,63 @@
+package sift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// packageHeaderView shows the name of the package, highlighting packages which
+// failed to build. vet failures are marked differently from compiler errors
+func packageHeaderView(test *tests.TestNode) string {
+	if test.Ref.Test != "" {
+		return styleSecondary.Render(test.Ref.Package)
+	}
+
+	if test.Status == tests.StatusVetError {
+		return styleVetError.Render(glyphs.VetError+" ") + styleSecondary.Foreground(colorMutedOrange).Render(test.Ref.Package)
+	}
+
+	style := styleSecondary.Foreground(colorMutedRed)
+	return style.Foreground(colorRed).Render("! ") + style.Render(test.Ref.Package)
+}
+
+// diagnosticCount shows how many compiler errors a package which failed to
+// build has, or how many issues vet found
+func diagnosticCount(test *tests.TestNode) string {
+	noun := "error"
+	if test.Status == tests.StatusVetError {
+		noun = "issue"
+	}
+
+	switch n := len(test.Diagnostics); n {
+	case 0:
+		return ""
+	case 1:
+		return styleSecondary.Render(" 1 " + noun)
+	default:
+		return styleSecondary.Render(fmt.Sprintf(" %d %ss", n, noun))
+	}
+}
+
+// diagnosticView renders a compiler error with its location separated from the message
+func diagnosticView(diagnostic outputparse.Diagnostic, baseStyle lipgloss.Style) string {
+	location := fmt.Sprintf("%s:%d", diagnostic.File, diagnostic.Line)
+	if diagnostic.Column > 0 {
+		location += fmt.Sprintf(":%d", diagnostic.Column)
+	}
+
+	messageStyle := lipgloss.NewStyle().Foreground(colorMutedRed).Inherit(baseStyle)
+
+	lines := strings.Split(diagnostic.Message, "\n")
+	lines[0] = styleSecondary.Inherit(baseStyle).Render(location+":") + " " + messageStyle.Render(lines[0])
+
+	// the lines which continue the message, such as have and want, are indented under it
+	for i, line := range lines[1:] {
+		lines[i+1] = "    " + messageStyle.Render(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,158 @@
+package sift
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/pkg/logparse"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// groupDiffLogs joins consecutive logs which form a diff into a single log, so
+// the diff can be folded and copied as one unit. The logs are only copied when
+// a diff is found
+func groupDiffLogs(logs []logparse.LogEntry) []logparse.LogEntry {
+	messages := make([]string, len(logs))
+	for i, log := range logs {
+		messages[i] = log.Message
+	}
+
+	var (
+		grouped []logparse.LogEntry
+		next    int
+	)
+
+	for _, block := range outputparse.FindDiffBlocks(messages) {
+		// logs with continuation lines are already grouped by the testing package
+		hasContinuation := slices.ContainsFunc(logs[block.Start:block.End], func(log logparse.LogEntry) bool {
+			return len(log.Continuation) > 0
+		})
+		if hasContinuation || block.End-block.Start < 2 {
+			continue
+		}
+
+		entry := logs[block.Start]
+		entry.Continuation = slices.Clone(messages[block.Start+1 : block.End])
+
+		grouped = append(grouped, logs[next:block.Start]...)
+		grouped = append(grouped, entry)
+		next = block.End
+	}
+
+	if grouped == nil {
+		return logs
+	}
+
+	return append(grouped, logs[next:]...)
+}
+
+// diffGutter is the marker shown in the gutter for each kind of line
+func diffGutter(kind outputparse.DiffLineKind) string {
+	switch kind {
+	case outputparse.DiffRemoved:
+		return "-"
+	case outputparse.DiffAdded:
+		return "+"
+	default:
+		return " "
+	}
+}
+
+func getDiffKindStyle(kind outputparse.DiffLineKind, baseStyle lipgloss.Style) lipgloss.Style {
+	switch kind {
+	case outputparse.DiffRemoved:
+		return styleDiffRemoved.Inherit(baseStyle)
+	case outputparse.DiffAdded:
+		return styleDiffAdded.Inherit(baseStyle)
+	case outputparse.DiffHunk:
+		return styleDiffHunk.Inherit(baseStyle)
+	case outputparse.DiffHeader:
+		return baseStyle
+	default:
+		return styleSecondary.Inherit(baseStyle)
+	}
+}
+
+// diffSummary counts the removed and added lines of the diffs, eg. -2 +3
+func diffSummary(lines []string, blocks []outputparse.DiffBlock) string {
+	removed, added := 0, 0
+	for _, block := range blocks {
+		for _, line := range lines[block.Start:block.End] {
+			switch outputparse.ParseDiffLine(line, block.Indent).Kind {
+			case outputparse.DiffRemoved:
+				removed++
+			case outputparse.DiffAdded:
+				added++
+			}
+		}
+	}
+
+	return fmt.Sprintf("-%d +%d", removed, added)
+}
+
+// diffView renders the lines of a log with any diffs colored line by line. The
+// markers of the diff are moved into a gutter so the lines stay aligned. When
+// folded only the first line is shown, along with how much has changed
+func diffView(lines []string, baseStyle lipgloss.Style, folded bool) (string, bool) {
+	blocks := outputparse.FindDiffBlocks(lines)
+	if len(blocks) == 0 {
+		return "", false
+	}
+
+	if folded {
+		summary := styleSecondary.Inherit(baseStyle).Render(fmt.Sprintf(" [%s, %d lines folded]", diffSummary(lines, blocks), len(lines)-1))
+		return baseStyle.Render(lines[0]) + summary, true
+	}
+
+	rendered := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); {
+		blockIdx := slices.IndexFunc(blocks, func(block outputparse.DiffBlock) bool {
+			return block.Start == i
+		})
+		if blockIdx < 0 {
+			rendered = append(rendered, baseStyle.Render(lines[i]))
+			i++
+			continue
+		}
+
+		block := blocks[blockIdx]
+		for _, line := range lines[block.Start:block.End] {
+			diffLine := outputparse.ParseDiffLine(line, block.Indent)
+			style := getDiffKindStyle(diffLine.Kind, baseStyle)
+
+			switch diffLine.Kind {
+			case outputparse.DiffHeader, outputparse.DiffHunk:
+				rendered = append(rendered, style.Render(line))
+			default:
+				gutter := style.Render(diffGutter(diffLine.Kind)) + styleSecondary.Render(glyphs.TreeBar)
+				rendered = append(rendered, strings.Repeat(" ", block.Indent)+gutter+style.Render(diffLine.Text))
+			}
+		}
+
+		i = block.End
+	}
+
+	return strings.Join(rendered, "\n"), true
+}
+
+// copyToClipboard copies the text with an OSC52 escape sequence, which also
+// works over ssh. It's written to stderr so it isn't interleaved with the
+// frames rendered to stdout
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+			seq = seq.Screen()
+		} else if os.Getenv("TMUX") != "" {
+			seq = seq.Tmux()
+		}
+
+		_, _ = seq.WriteTo(os.Stderr)
+		return nil
+	}
+}
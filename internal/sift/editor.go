@@ -0,0 +1,128 @@
+package sift
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/logparse"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// logLocation finds where in the code a log was written. The error trace of a
+// testify assertion is preferred as it is an absolute path
+func logLocation(log logparse.LogEntry) (string, int, bool) {
+	if failure, ok := parseAssertionFailure(log); ok && failure.File != "" && failure.Line > 0 {
+		return failure.File, failure.Line, true
+	}
+
+	if diagnostic, ok := tests.LogDiagnostic(log); ok {
+		return diagnostic.File, diagnostic.Line, true
+	}
+
+	return outputparse.ParseLogLocation(log.Message)
+}
+
+// packageDirs caches the directory of each package, as `go list` is too slow
+// to run each time an editor is opened
+type packageDirs struct {
+	mu   sync.Mutex
+	dirs map[string]string
+}
+
+func newPackageDirs() *packageDirs {
+	return &packageDirs{
+		dirs: make(map[string]string),
+	}
+}
+
+func (pd *packageDirs) get(pkg string) (string, error) {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+
+	if dir, ok := pd.dirs[pkg]; ok {
+		return dir, nil
+	}
+
+	out, err := exec.Command("go", "list", "-f", "{{.Dir}}", pkg).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the directory of %s: %w", pkg, err)
+	}
+
+	dir := strings.TrimSpace(string(out))
+	pd.dirs[pkg] = dir
+
+	return dir, nil
+}
+
+// resolve finds the file of a location. The testing package only prints the
+// name of the file, which is relative to the directory of the package. The
+// compiler prints paths relative to where `go test` was run instead
+func (pd *packageDirs) resolve(pkg, file string) (string, error) {
+	if filepath.IsAbs(file) {
+		return file, nil
+	}
+
+	// test builds are named after the package they're for, eg. foo [foo.test]
+	pkg, _, _ = strings.Cut(pkg, " ")
+
+	if dir, err := pd.get(pkg); err == nil {
+		path := filepath.Join(dir, file)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	if _, err := os.Stat(file); err == nil {
+		return filepath.Abs(file)
+	}
+
+	return "", fmt.Errorf("unable to find %s in %s", file, pkg)
+}
+
+// editorCommand builds the command to open the file at the line. The editor
+// is taken from $VISUAL or $EDITOR, which may include arguments
+func editorCommand(path string, line int) (*exec.Cmd, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("$EDITOR is not set")
+	}
+
+	args = append(args, fmt.Sprintf("+%d", line), path)
+
+	return exec.Command(args[0], args[1:]...), nil
+}
+
+type editorFinishedMsg struct {
+	err error
+}
+
+// openEditor suspends the program while the editor is open. Resolving the
+// path may need to run `go list`, so it's done within the command
+func (m *siftModel) openEditor(pkg, file string, line int) tea.Cmd {
+	return func() tea.Msg {
+		path, err := m.packageDirs.resolve(pkg, file)
+		if err != nil {
+			return editorFinishedMsg{err: err}
+		}
+
+		cmd, err := editorCommand(path, line)
+		if err != nil {
+			return editorFinishedMsg{err: err}
+		}
+
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return editorFinishedMsg{err: err}
+		})()
+	}
+}
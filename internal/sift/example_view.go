@@ -0,0 +1,112 @@
+package sift
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/linediff"
+	"github.com/timtatt/sift/pkg/logparse"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// groupExampleLogs joins the got and want output of a failed example into a
+// single log, as the testing package prints each line as a separate log. The
+// log is marked as an error as it is the failure of the example
+func groupExampleLogs(testRef tests.TestReference, logs []logparse.LogEntry) []logparse.LogEntry {
+	if !testRef.IsExample() {
+		return logs
+	}
+
+	start := slices.IndexFunc(logs, func(log logparse.LogEntry) bool {
+		return log.Message == "got:"
+	})
+	if start < 0 || start == len(logs)-1 {
+		return logs
+	}
+
+	entry := logs[start]
+	entry.OutputType = logparse.OutputTypeError
+	entry.Continuation = nil
+	for _, log := range logs[start+1:] {
+		entry.Continuation = append(entry.Continuation, log.Lines()...)
+	}
+
+	if _, ok := outputparse.ParseExampleFailure(entry.Lines()); !ok {
+		return logs
+	}
+
+	return append(slices.Clone(logs[:start]), entry)
+}
+
+// exampleDiff diffs the output of the example against the output it wanted.
+// Unordered output is sorted first, as the order of its lines doesn't matter
+func exampleDiff(failure outputparse.ExampleFailure) []linediff.Line {
+	want, got := failure.Want, failure.Got
+	if failure.Unordered {
+		want, got = slices.Sorted(slices.Values(want)), slices.Sorted(slices.Values(got))
+	}
+
+	return linediff.Diff(want, got)
+}
+
+func getDiffKind(op linediff.Op) outputparse.DiffLineKind {
+	switch op {
+	case linediff.Delete:
+		return outputparse.DiffRemoved
+	case linediff.Insert:
+		return outputparse.DiffAdded
+	default:
+		return outputparse.DiffContext
+	}
+}
+
+// exampleView renders the output of a failed example as a diff of the wanted
+// output against the output it got, in the same form as other diffs
+func exampleView(failure outputparse.ExampleFailure, baseStyle lipgloss.Style, folded bool) string {
+	header := "output mismatch (-want +got):"
+	if failure.Unordered {
+		header = "unordered output mismatch (-want +got):"
+	}
+
+	diff := exampleDiff(failure)
+
+	if folded {
+		removed, added := 0, 0
+		for _, line := range diff {
+			switch line.Op {
+			case linediff.Delete:
+				removed++
+			case linediff.Insert:
+				added++
+			}
+		}
+
+		summary := fmt.Sprintf(" [-%d +%d, %d lines folded]", removed, added, len(diff))
+		return baseStyle.Render(header) + styleSecondary.Inherit(baseStyle).Render(summary)
+	}
+
+	rendered := make([]string, 0, len(diff)+1)
+	rendered = append(rendered, baseStyle.Render(header))
+
+	for _, line := range diff {
+		kind := getDiffKind(line.Op)
+		style := getDiffKindStyle(kind, baseStyle)
+
+		gutter := style.Render(diffGutter(kind)) + styleSecondary.Render(glyphs.TreeBar)
+		rendered = append(rendered, gutter+style.Render(line.Text))
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+// getExampleBadge labels example tests in the tree
+func getExampleBadge(testRef tests.TestReference) string {
+	if !testRef.IsExample() {
+		return ""
+	}
+
+	return " " + styleBadge.Render("[example]")
+}
@@ -0,0 +1,46 @@
+package sift
+
+import (
+	"fmt"
+
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+// fuzzView renders the live status of a fuzz target, and how to reproduce it
+// once it has found a failing input
+func fuzzView(test *tests.TestNode, indent string) string {
+	fs := test.Fuzz
+	if fs == nil {
+		return ""
+	}
+
+	progress := fs.Progress
+
+	stats := fmt.Sprintf(
+		"fuzz elapsed: %s, execs: %d (%d/sec), new interesting: %d (total: %d)",
+		formatDuration(progress.Elapsed),
+		progress.Execs,
+		progress.ExecsPerSec,
+		progress.NewInteresting,
+		progress.TotalInteresting,
+	)
+
+	// the phase is only relevant while the fuzzer is still running
+	if progress.Phase != "" && !test.Status.Done() {
+		stats += ", " + progress.Phase
+	}
+
+	vb := viewbuilder.New()
+	vb.Add(indent + "  " + styleSecondary.Render(stats))
+	vb.AddLine()
+
+	if fs.FailingInput != "" {
+		vb.Add(indent + "  " + styleCross.Render("failing input: ") + fs.FailingInput)
+		vb.AddLine()
+		vb.Add(indent + "  " + styleSecondary.Render("reproduce: ") + styleHighlighted.Render(fs.ReproduceCommand(test.Ref)))
+		vb.AddLine()
+	}
+
+	return vb.String()
+}
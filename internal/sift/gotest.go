@@ -0,0 +1,137 @@
+package sift
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/timtatt/sift/internal/tests"
+)
+
+// RunGoTest runs `go test -json` with the args instead of reading its output
+// from stdin. the tests are listed alongside the run so the tests which are
+// queued are shown before they start
+func (s *sift) RunGoTest(ctx context.Context, args []string) error {
+	listed := make(chan struct{})
+	go func() {
+		defer close(listed)
+		s.queueTests(ctx, args)
+	}()
+
+	cmd := exec.CommandContext(ctx, "go", append([]string{"test", "-json"}, args...)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to run go test: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run go test: %w", err)
+	}
+
+	scanErr := scanTestOutput(stdout, s.model.testManager)
+	if scanErr != nil {
+		// go test would otherwise block writing to the pipe
+		_ = cmd.Process.Kill()
+	}
+
+	err = cmd.Wait()
+	<-listed
+
+	s.model.endTime = time.Now()
+
+	if ctx.Err() != nil {
+		return nil
+	} else if scanErr != nil {
+		return scanErr
+	}
+
+	// go test exits with an error when a test fails, which is shown in the
+	// tree. only errors which go test wrote to stderr are reported
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && stderr.Len() == 0 {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("go test failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// queueTests lists the tests with `go test -list` and adds them to the tree as
+// queued. it's best effort, as the tests are shown as they run regardless
+func (s *sift) queueTests(ctx context.Context, args []string) {
+	listArgs := append([]string{"test", "-json", "-list", listPattern(args)}, args...)
+
+	out, err := exec.CommandContext(ctx, "go", listArgs...).Output()
+	if err != nil && len(out) == 0 {
+		return
+	}
+
+	for pkg, names := range parseTestList(bytes.NewReader(out)) {
+		s.model.testManager.QueueTests(pkg, names)
+	}
+}
+
+// listPattern finds the pattern which selects the tests to list from the -run
+// flag. -list only matches top level tests, so only the first part of a
+// pattern for subtests is used
+func listPattern(args []string) string {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || (name != "run" && name != "test.run") {
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				break
+			}
+			value = args[i+1]
+		}
+
+		pattern, _, _ := strings.Cut(value, "/")
+		if pattern != "" {
+			return pattern
+		}
+	}
+
+	return "."
+}
+
+// benchmarks are listed too but only run with -bench, so they aren't queued
+var listedTestRegex = regexp.MustCompile(`^(Test|Example|Fuzz)\S*$`)
+
+// parseTestList reads the names of the tests in each package from the output
+// of `go test -json -list`
+func parseTestList(r io.Reader) map[string][]string {
+	list := make(map[string][]string)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var line tests.TestOutputLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+
+		name := strings.TrimRight(line.Output, "\n")
+		if line.Action != "output" || line.Test != "" || !listedTestRegex.MatchString(name) {
+			continue
+		}
+
+		list[line.Package] = append(list[line.Package], name)
+	}
+
+	return list
+}
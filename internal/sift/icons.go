@@ -1,15 +1,143 @@
 package sift
 
-func (m *siftModel) getStatusIcon(status string) string {
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/timtatt/sift/internal/tests"
+)
+
+type glyphSet struct {
+	Logo     string
+	Pass     string
+	Fail     string
+	Skip     string
+	Paused   string
+	Queued   string
+	Aborted  string
+	TimedOut string
+	VetError string
+	Race     string
+	TreeBar  string
+	Pulse    string
+	Up       string
+	Down     string
+
+	TimelineRun   string
+	TimelinePause string
+
+	ProgressDone string
+	ProgressTodo string
+
+	SortAsc  string
+	SortDesc string
+
+	HelpSeparator string
+	Ellipsis      string
+
+	Compiling spinner.Spinner
+}
+
+var (
+	unicodeGlyphs = glyphSet{
+		Logo:          "∇",
+		Pass:          "✓",
+		Fail:          "×",
+		Skip:          "⏭",
+		Paused:        "‖",
+		Queued:        "○",
+		Aborted:       "⊘",
+		TimedOut:      "⏱",
+		VetError:      "⚠",
+		Race:          "↯",
+		TreeBar:       "│",
+		Pulse:         "∙",
+		Up:            "↑",
+		Down:          "↓",
+		TimelineRun:   "█",
+		TimelinePause: "░",
+		ProgressDone:  "█",
+		ProgressTodo:  "░",
+		SortAsc:       "▲",
+		SortDesc:      "▼",
+		HelpSeparator: " • ",
+		Ellipsis:      "…",
+		Compiling:     spinner.Dot,
+	}
+
+	asciiGlyphs = glyphSet{
+		Logo:          "V",
+		Pass:          "+",
+		Fail:          "x",
+		Skip:          "-",
+		Paused:        "=",
+		Queued:        "o",
+		Aborted:       "!",
+		TimedOut:      "t",
+		VetError:      "v",
+		Race:          "r",
+		TreeBar:       "|",
+		Pulse:         ".",
+		Up:            "up",
+		Down:          "down",
+		TimelineRun:   "#",
+		TimelinePause: ".",
+		ProgressDone:  "#",
+		ProgressTodo:  "-",
+		SortAsc:       "^",
+		SortDesc:      "v",
+		HelpSeparator: " - ",
+		Ellipsis:      "...",
+		Compiling:     spinner.Line,
+	}
+
+	glyphs = unicodeGlyphs
+)
+
+// useASCII swaps every glyph for an ASCII equivalent for terminals which are
+// unable to render unicode
+func useASCII() {
+	glyphs = asciiGlyphs
+
+	keys.Up.SetHelp(glyphs.Up+"/k", "move up")
+	keys.Down.SetHelp(glyphs.Down+"/j", "move down")
+
+	// rebuild the spinner with the new glyphs
+	setTheme(currentTheme)
+}
+
+// getTestIcon shows a dedicated icon for tests which failed due to a data race,
+// or which have been running for long enough that they may be hung
+func (m *siftModel) getTestIcon(test *tests.TestNode) string {
+	if test.Status == tests.StatusFailed && len(test.Races) > 0 {
+		return styleCross.Render(glyphs.Race)
+	}
+
+	if m.isHung(test) {
+		return styleTimedOut.Render(glyphs.TimedOut)
+	}
+
+	return m.getStatusIcon(test.Status)
+}
+
+func (m *siftModel) getStatusIcon(status tests.TestStatus) string {
 	switch status {
-	case "skip":
-		return styleSkip.Render("\u23ED")
-	case "run":
+	case tests.StatusQueued:
+		return styleSecondary.Render(glyphs.Queued)
+	case tests.StatusSkipped:
+		return styleSkip.Render(glyphs.Skip)
+	case tests.StatusRunning:
 		return styleProgress.Render(m.runningSpinner.View())
-	case "fail":
-		return styleCross.Render("\u00D7")
-	case "pass":
-		return styleTick.Render("\u2713")
+	case tests.StatusPaused:
+		return styleSecondary.Render(glyphs.Paused)
+	case tests.StatusFailed:
+		return styleCross.Render(glyphs.Fail)
+	case tests.StatusPassed:
+		return styleTick.Render(glyphs.Pass)
+	case tests.StatusAborted:
+		return styleAborted.Render(glyphs.Aborted)
+	case tests.StatusTimedOut:
+		return styleTimedOut.Render(glyphs.TimedOut)
+	case tests.StatusVetError:
+		return styleVetError.Render(glyphs.VetError)
 	default:
 		return ""
 	}
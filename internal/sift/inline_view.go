@@ -19,29 +19,24 @@ func (m *siftModel) inlineView() string {
 	stack := newTestStack()
 	var lastPackage string
 
+	subtests := m.testManager.GetSubtestCounts()
+	packages := m.testManager.GetPackages()
+
 	for _, test := range m.testManager.GetTests {
-		summary.AddToPackage(test.Ref.Package, test.Status)
+		summary.AddTest(test)
 
 		if test.Ref.Package != lastPackage {
 			if lastPackage != "" {
 				vb.AddLine()
 			}
 
-			style := styleSecondary
-			prefix := ""
-
-			if test.Ref.Test == "" {
-				style = style.Foreground(colorMutedRed)
-				prefix = style.Foreground(colorRed).Render("! ")
-			}
-
-			vb.Add(prefix + style.Render(test.Ref.Package))
+			vb.Add(packageHeaderView(test) + diagnosticCount(test) + packageElapsed(packages[test.Ref.Package]))
 			vb.AddLine()
 			lastPackage = test.Ref.Package
 		}
 
 		if test.Ref.Test != "" {
-			statusIcon := m.getStatusIcon(test.Status)
+			statusIcon := m.getTestIcon(test)
 
 			prefixTest := stack.PopUntilPrefix(test.Ref.Test)
 			testName, _ := strings.CutPrefix(test.Ref.Test, prefixTest)
@@ -50,16 +45,24 @@ func (m *siftModel) inlineView() string {
 			indent := getIndentWithBars(indentLevel)
 
 			elapsed := ""
-			if test.Status != "run" {
+			if test.Status.Done() {
 				elapsed = styleSecondary.Render(
 					formatDuration(test.Elapsed),
 				)
 			}
 
-			vb.Add(fmt.Sprintf("%s%s %s %s", indent, statusIcon, testName, elapsed))
+			vb.Add(fmt.Sprintf("%s%s %s %s%s", indent, statusIcon, testName, elapsed, getSkipReason(test)+getSubtestFailures(subtests[test.Ref])+getExampleBadge(test.Ref)+getAttrBadges(test.Attrs)))
 			vb.AddLine()
+			vb.Add(raceView(test, indent, true))
+			vb.Add(panicView(test, indent, true))
+			vb.Add(fuzzView(test, indent))
 		} else {
 			for _, logEntry := range m.testManager.GetLogs(test.Ref) {
+				if diagnostic, ok := tests.LogDiagnostic(logEntry); ok && test.Status.BuildFailure() {
+					vb.Add(diagnosticView(diagnostic, styleLog))
+					vb.AddLine()
+					continue
+				}
 
 				prettifiedLog := prettifyLogEntry(logEntry, styleLog)
 				vb.Add(fmt.Sprintf("%s", prettifiedLog))
@@ -71,6 +74,15 @@ func (m *siftModel) inlineView() string {
 		stack.Push(test.Ref.Test)
 	}
 
+	// the skipped tests are listed by reason once the run is over, so they can
+	// be audited from the output of CI
+	if !m.endTime.IsZero() && summary.Total().Skipped > 0 {
+		vb.AddLine()
+		vb.Add(styleHeader.Render("Skipped"))
+		vb.AddLine()
+		vb.Add(m.skippedView())
+	}
+
 	vb.AddLine()
 	vb.Add(m.summaryView(summary))
 
@@ -78,7 +90,7 @@ func (m *siftModel) inlineView() string {
 		vb.AddLine()
 		vb.AddLine()
 		total := summary.Total()
-		if total.Failed > 0 {
+		if total.Failing() {
 			vb.Add(styleOutcomeFail.Render("FAILED"))
 		} else {
 			vb.Add(styleOutcomePass.Render("PASSED"))
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/outputparse"
 	"github.com/timtatt/sift/pkg/viewbuilder"
 )
 
@@ -14,18 +15,28 @@ func (m *siftModel) interactiveView() string {
 	s := ""
 
 	var header string
-	header += styleHeader.Render("\u2207 sift")
+	header += styleHeader.Render(glyphs.Logo + " sift")
 
 	if m.autoToggleMode {
 		header += styleSecondary.Render(" [AUTO TOGGLE MODE]")
 	}
 
+	if m.failuresOnly {
+		header += styleSecondary.Render(" [FAILURES ONLY]")
+	}
+
+	if m.zoom != nil {
+		header += styleSecondary.Render(" [ZOOM: " + m.zoom.Test + "]")
+	}
+
 	header += " " + lipgloss.NewStyle().Foreground(colorMutedBlue).Render(Version)
 
 	if m.opts.Debug {
 		header += fmt.Sprintf(" cursor: [%d, %d] %d | yoffset: %d, bottom %d", m.cursor.test, m.cursor.log, m.GetCursorPos(), m.viewport.YOffset, m.viewport.YOffset+m.viewport.Height)
 
 	}
+	header += "\n\n" + m.tabBarView()
+
 	if m.searchInput.Focused() {
 		header += "\n\n" + m.searchInput.View()
 	} else if m.searchInput.Value() != "" {
@@ -40,25 +51,58 @@ func (m *siftModel) interactiveView() string {
 	}
 
 	if m.started {
-		testView, summary := m.testView()
+		content, summary := m.testView()
+		if m.tab != tabTests {
+			content = m.tabView()
+		}
 
-		m.viewport.SetContent(testView)
+		m.viewport.SetContent(content)
 
 		var footer string
 		footer += "\n"
+
+		if m.endTime.IsZero() {
+			if progress := m.progressView(time.Now()); progress != "" && len(m.opts.GoTestArgs) > 0 {
+				footer += progress + "\n\n"
+			}
+
+			if running := m.runningView(time.Now()); running != "" {
+				footer += running + "\n\n"
+			}
+		}
+
 		footer += m.summaryView(summary)
 
+		// only show the preview when it leaves enough room for the tests
+		if preview := m.previewView(); preview != "" && m.tab == tabTests {
+			remainingHeight := m.windowSize.Height - lipgloss.Height(header) - lipgloss.Height(footer) - lipgloss.Height(preview)
+			if remainingHeight >= minPreviewViewportHeight {
+				footer = "\n" + preview + "\n" + footer
+			}
+		}
+
+		if m.editorErr != nil {
+			footer += "\n\n"
+			footer += styleCross.Render(m.editorErr.Error())
+		}
+
 		if statusView := m.statusView(summary); statusView != "" {
 			footer += "\n\n"
 			footer += statusView
 		}
 
+		// the keys of an incomplete command are shown after the help, as vim does
+		helpView := m.help.View(keys)
+		if pending := m.keySequence.String(); pending != "" {
+			helpView = lipgloss.JoinHorizontal(lipgloss.Bottom, helpView, "  ", styleHighlighted.Render(pending))
+		}
+
 		footer += "\n"
-		footer += lipgloss.NewStyle().PaddingTop(1).Render(m.help.View(keys))
+		footer += lipgloss.NewStyle().PaddingTop(1).Render(helpView)
 
-		testViewHeight := lipgloss.Height(testView)
-		maxTestViewHeight := m.windowSize.Height - lipgloss.Height(footer) - lipgloss.Height(header)
-		m.viewport.Height = min(testViewHeight, maxTestViewHeight)
+		contentHeight := lipgloss.Height(content)
+		maxContentHeight := m.windowSize.Height - lipgloss.Height(footer) - lipgloss.Height(header)
+		m.viewport.Height = min(contentHeight, maxContentHeight)
 
 		s += m.viewport.View()
 
@@ -74,7 +118,7 @@ func (m *siftModel) statusView(summary *tests.Summary) string {
 
 	if m.endTime.IsZero() {
 		return ""
-	} else if total.Failed > 0 {
+	} else if total.Failing() {
 		return styleOutcomeFail.Render("FAILED")
 	}
 
@@ -93,6 +137,15 @@ func formatDuration(d time.Duration) string {
 	}
 }
 
+// packageElapsed shows how long the package took once it has finished
+func packageElapsed(pkgNode tests.PackageNode) string {
+	if !pkgNode.Status.Done() || pkgNode.Status.BuildFailure() {
+		return ""
+	}
+
+	return " " + styleSecondary.Render(formatDuration(pkgNode.Elapsed))
+}
+
 func (m *siftModel) testView() (string, *tests.Summary) {
 	vb := viewbuilder.New()
 
@@ -101,6 +154,9 @@ func (m *siftModel) testView() (string, *tests.Summary) {
 	stack := newTestStack()
 	var lastPackage string
 
+	subtests := m.testManager.GetSubtestCounts()
+	packages := m.testManager.GetPackages()
+
 	for i, test := range m.testManager.GetTests {
 
 		ts, ok := m.testState[test.Ref]
@@ -123,25 +179,16 @@ func (m *siftModel) testView() (string, *tests.Summary) {
 				vb.AddLine()
 			}
 
-			style := styleSecondary
-			prefix := ""
-
-			// if the pkg had a build error, highlight it in red
-			if test.Ref.Test == "" {
-				style = style.Foreground(colorMutedRed)
-				prefix = style.Foreground(colorRed).Render("! ")
-			}
-
-			vb.Add(prefix + style.Render(test.Ref.Package))
+			vb.Add(packageHeaderView(test) + diagnosticCount(test) + packageElapsed(packages[test.Ref.Package]))
 			vb.AddLine()
 			lastPackage = test.Ref.Package
 		}
 
 		testHighlighted := m.cursor.test == i
 
-		summary.AddToPackage(test.Ref.Package, test.Status)
+		summary.AddTest(test)
 
-		statusIcon := m.getStatusIcon(test.Status)
+		statusIcon := m.getTestIcon(test)
 
 		prefixTest := stack.PopUntilPrefix(test.Ref.Test)
 		testName, _ := strings.CutPrefix(test.Ref.Test, prefixTest)
@@ -155,7 +202,7 @@ func (m *siftModel) testView() (string, *tests.Summary) {
 			}
 
 			elapsed := ""
-			if test.Status != "run" {
+			if test.Status.Done() {
 				elapsed = styleSecondary.Render(
 					formatDuration(test.Elapsed),
 				)
@@ -163,7 +210,7 @@ func (m *siftModel) testView() (string, *tests.Summary) {
 
 			ts.viewportPos = vb.Lines()
 
-			vb.Add(fmt.Sprintf("%s%s %s %s", indent, statusIcon, testName, elapsed))
+			vb.Add(fmt.Sprintf("%s%s %s %s%s", indent, statusIcon, testName, elapsed, getSkipReason(test)+getSubtestFailures(subtests[test.Ref])+getExampleBadge(test.Ref)+getAttrBadges(test.Attrs)))
 			if m.opts.Debug {
 				vb.Add(fmt.Sprintf(" [%d]", ts.viewportPos))
 			}
@@ -171,9 +218,11 @@ func (m *siftModel) testView() (string, *tests.Summary) {
 		}
 
 		if ts.toggled {
-			logs := m.testManager.GetLogs(test.Ref)
+			logs := m.getLogs(test.Ref)
+			ts.logPositions = ts.logPositions[:0]
 
 			for logIdx, log := range logs {
+				ts.logPositions = append(ts.logPositions, vb.Lines())
 
 				logStyle := lipgloss.NewStyle()
 				prefix := "  "
@@ -184,11 +233,24 @@ func (m *siftModel) testView() (string, *tests.Summary) {
 					logStyle = styleSecondary
 				}
 
+				// make failure output from t.Error stand out from application logs
+				if log.IsError() {
+					logStyle = logStyle.Foreground(colorMutedRed)
+				}
+
 				var styledLog string
-				if m.opts.PrettifyLogs {
+				if diagnostic, ok := tests.LogDiagnostic(log); ok && test.Status.BuildFailure() {
+					styledLog = diagnosticView(diagnostic, logStyle)
+				} else if failure, ok := outputparse.ParseExampleFailure(log.Lines()); ok && test.Ref.IsExample() {
+					styledLog = exampleView(failure, logStyle, ts.foldedDiffs[log.Index])
+				} else if failure, ok := parseAssertionFailure(log); ok {
+					styledLog = assertionView(failure, logStyle)
+				} else if diff, ok := diffView(log.Lines(), logStyle, ts.foldedDiffs[log.Index]); ok {
+					styledLog = diff
+				} else if m.opts.PrettifyLogs {
 					styledLog = prettifyLogEntry(log, logStyle)
 				} else {
-					styledLog = logStyle.Render(log.Message)
+					styledLog = logStyle.Render(strings.Join(log.Lines(), "\n"))
 				}
 
 				styledLog = styleLog.Width(m.viewport.Width - 2).Render(styledLog)
@@ -198,6 +260,10 @@ func (m *siftModel) testView() (string, *tests.Summary) {
 			}
 		}
 
+		vb.Add(raceView(test, indent, ts.toggled))
+		vb.Add(panicView(test, indent, ts.toggled))
+		vb.Add(fuzzView(test, indent))
+
 		stack.Push(test.Ref.Test)
 	}
 
@@ -216,6 +282,26 @@ func getDisplayName(testName string) string {
 	return testName[lastSlash+1:]
 }
 
+// getSubtestFailures shows how many subtests failed under a test which failed
+// because of its subtests
+func getSubtestFailures(subtests tests.SubtestCount) string {
+	if subtests.Failed == 0 {
+		return ""
+	}
+
+	return " " + lipgloss.NewStyle().Foreground(colorMutedRed).Render(fmt.Sprintf("%d of %d subtests failed", subtests.Failed, subtests.Total))
+}
+
+func getAttrBadges(attrs []tests.TestAttr) string {
+	var badges strings.Builder
+	for _, attr := range attrs {
+		badges.WriteString(" ")
+		badges.WriteString(styleBadge.Render(fmt.Sprintf("[%s=%s]", attr.Key, attr.Value)))
+	}
+
+	return badges.String()
+}
+
 func getIndentWithBars(indentLevel int) string {
 	if indentLevel == 0 {
 		return ""
@@ -223,7 +309,7 @@ func getIndentWithBars(indentLevel int) string {
 
 	var indent strings.Builder
 	for range indentLevel {
-		indent.WriteString(styleSecondary.Render("│ "))
+		indent.WriteString(styleSecondary.Render(glyphs.TreeBar + " "))
 	}
 
 	return indent.String()
@@ -0,0 +1,123 @@
+package sift
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyCommand is a command read from one or more keys
+type keyCommand struct {
+	keys  string // the keys of the command, without the count or register
+	count int    // 0 when no count was typed
+
+	// register is the key following a command which takes one, eg. the a of ma
+	register string
+}
+
+// Count is how many times the command should be repeated
+func (c keyCommand) Count() int {
+	return max(1, c.count)
+}
+
+func (c keyCommand) Matches(bindings ...key.Binding) bool {
+	for _, binding := range bindings {
+		if binding.Enabled() && slices.Contains(binding.Keys(), c.keys) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// keySequence reads vim style commands which span multiple keys. A command
+// can be preceded by a count (5j), be a sequence of keys (zA, gg) or be
+// followed by a register (ma, 'a)
+type keySequence struct {
+	// sequences are the commands made of more than one key
+	sequences []string
+
+	// registerCommands are the commands which are followed by a register
+	registerCommands []string
+
+	count   string
+	pending string
+}
+
+func newKeySequence(sequences, registerCommands []key.Binding) *keySequence {
+	ks := &keySequence{}
+
+	for _, binding := range sequences {
+		ks.sequences = append(ks.sequences, binding.Keys()...)
+	}
+
+	for _, binding := range registerCommands {
+		ks.registerCommands = append(ks.registerCommands, binding.Keys()...)
+	}
+
+	return ks
+}
+
+func (ks *keySequence) reset() {
+	ks.count = ""
+	ks.pending = ""
+}
+
+// isRegister checks if the key names a register, which are the letters a-z
+func isRegister(k string) bool {
+	return len(k) == 1 && k[0] >= 'a' && k[0] <= 'z'
+}
+
+// Feed adds a key to the sequence, returning the command once it's complete.
+// Keys which don't continue the pending sequence discard it, as vim does
+func (ks *keySequence) Feed(k string) (keyCommand, bool) {
+	if k == "esc" && ks.String() != "" {
+		ks.reset()
+		return keyCommand{}, false
+	}
+
+	// a count can't start with 0
+	if ks.pending == "" && len(k) == 1 && k[0] >= '0' && k[0] <= '9' && (k != "0" || ks.count != "") {
+		ks.count += k
+		return keyCommand{}, false
+	}
+
+	count, _ := strconv.Atoi(ks.count)
+
+	if slices.Contains(ks.registerCommands, ks.pending) {
+		command := keyCommand{keys: ks.pending, count: count, register: k}
+		ks.reset()
+
+		return command, isRegister(k)
+	}
+
+	sequence := ks.pending + k
+
+	if slices.Contains(ks.sequences, sequence) {
+		ks.reset()
+		return keyCommand{keys: sequence, count: count}, true
+	}
+
+	isPrefix := slices.ContainsFunc(ks.sequences, func(s string) bool {
+		return strings.HasPrefix(s, sequence)
+	})
+	if isPrefix || slices.Contains(ks.registerCommands, sequence) {
+		ks.pending = sequence
+		return keyCommand{}, false
+	}
+
+	if ks.pending != "" {
+		ks.reset()
+		return ks.Feed(k)
+	}
+
+	ks.reset()
+	return keyCommand{keys: k, count: count}, true
+}
+
+// String shows the keys typed of a command which isn't complete yet
+func (ks *keySequence) String() string {
+	return ks.count + ks.pending
+}
@@ -20,11 +20,33 @@ type keyMap struct {
 	ToggleTestAlt          key.Binding
 	ExpandTest             key.Binding
 	CollapseTest           key.Binding
+	ExpandLevel            key.Binding
+	CollapseLevel          key.Binding
+	ParentTest             key.Binding
+	FirstChild             key.Binding
+	PrevSibling            key.Binding
+	NextSibling            key.Binding
+	Zoom                   key.Binding
+	GotoTop                key.Binding
+	GotoBottom             key.Binding
+	ScreenTop              key.Binding
+	ScreenMiddle           key.Binding
+	ScreenBottom           key.Binding
+	SetMark                key.Binding
+	JumpToMark             key.Binding
 	Search                 key.Binding
 	ClearSearch            key.Binding
 	Help                   key.Binding
 	Quit                   key.Binding
 	ChangeMode             key.Binding
+	FailuresOnly           key.Binding
+	NextTab                key.Binding
+	PrevTab                key.Binding
+	SortBenchmarks         key.Binding
+	ShowSkipped            key.Binding
+	FoldDiff               key.Binding
+	CopyLog                key.Binding
+	OpenEditor             key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -33,11 +55,16 @@ func (k keyMap) ShortHelp() []key.Binding {
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.ChangeMode},
+		{k.Up, k.Down, k.ChangeMode, k.FailuresOnly},
 		{k.PrevTest, k.NextTest, k.PrevFailingTest, k.NextFailingTest},
+		{k.ParentTest, k.FirstChild, k.PrevSibling, k.NextSibling, k.Zoom},
+		{k.GotoTop, k.GotoBottom, k.ScreenTop, k.ScreenMiddle, k.ScreenBottom},
+		{k.SetMark, k.JumpToMark},
 		{k.viewport.Up, k.viewport.Down, k.viewport.HalfPageUp, k.viewport.HalfPageDown},
 		{k.ToggleTest, k.ExpandTest, k.CollapseTest},
-		{k.ToggleTestsRecursively, k.ExpandAllTests, k.CollapseAllTests},
+		{k.ToggleTestsRecursively, k.ExpandAllTests, k.CollapseAllTests, k.ExpandLevel, k.CollapseLevel},
+		{k.FoldDiff, k.CopyLog, k.OpenEditor},
+		{k.NextTab, k.PrevTab, k.SortBenchmarks, k.ShowSkipped},
 		{k.Search, k.ClearSearch, k.Help, k.Quit},
 	}
 }
@@ -63,8 +90,12 @@ var (
 			),
 		},
 		ChangeMode: key.NewBinding(
-			key.WithKeys("m"),
-			key.WithHelp("m", "change mode"),
+			key.WithKeys("a"),
+			key.WithHelp("a", "change mode"),
+		),
+		FailuresOnly: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "failures only"),
 		),
 		Up: key.NewBinding(
 			key.WithKeys("up", "k", "ctrl+p"),
@@ -118,6 +149,62 @@ var (
 			key.WithKeys("zc"),
 			key.WithHelp("zc", "collapse test"),
 		),
+		ExpandLevel: key.NewBinding(
+			key.WithKeys("zO"),
+			key.WithHelp("zO", "expand level"),
+		),
+		CollapseLevel: key.NewBinding(
+			key.WithKeys("zC"),
+			key.WithHelp("zC", "collapse level"),
+		),
+		ParentTest: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "parent test"),
+		),
+		FirstChild: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "first subtest"),
+		),
+		PrevSibling: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "prev sibling"),
+		),
+		NextSibling: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "next sibling"),
+		),
+		Zoom: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "zoom subtree"),
+		),
+		GotoTop: key.NewBinding(
+			key.WithKeys("gg"),
+			key.WithHelp("gg", "first test"),
+		),
+		GotoBottom: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "last test"),
+		),
+		ScreenTop: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "top of screen"),
+		),
+		ScreenMiddle: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "middle of screen"),
+		),
+		ScreenBottom: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "bottom of screen"),
+		),
+		SetMark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m{a-z}", "set mark"),
+		),
+		JumpToMark: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'{a-z}", "jump to mark"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "search tests"),
@@ -126,6 +213,34 @@ var (
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "clear search"),
 		),
+		NextTab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next tab"),
+		),
+		PrevTab: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "prev tab"),
+		),
+		SortBenchmarks: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort benchmarks"),
+		),
+		ShowSkipped: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "skipped tests"),
+		),
+		FoldDiff: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "fold diff"),
+		),
+		CopyLog: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy log"),
+		),
+		OpenEditor: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "open in editor"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle help"),
@@ -136,3 +251,15 @@ var (
 		),
 	}
 )
+
+// sequences reads the commands of the keymap which span multiple keys
+func (k keyMap) sequences() *keySequence {
+	return newKeySequence(
+		[]key.Binding{
+			k.ToggleTestsRecursively, k.ExpandAllTests, k.CollapseAllTests,
+			k.ToggleTest, k.ExpandTest, k.CollapseTest,
+			k.ExpandLevel, k.CollapseLevel, k.GotoTop,
+		},
+		[]key.Binding{k.SetMark, k.JumpToMark},
+	)
+}
@@ -0,0 +1,103 @@
+package sift
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/outputparse"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+// isTestFrame checks if the stack frame is part of the test itself, eg. for
+// the test TestFoo/bar the frames example.TestFoo and example.TestFoo.func1
+func isTestFrame(frame outputparse.StackFrame, testName string) bool {
+	parts := strings.Split(frame.Function, ".")
+
+	for name := range strings.SplitSeq(testName, "/") {
+		if slices.Contains(parts, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stackFrameView(frame outputparse.StackFrame, testName string) string {
+	function := frame.Function
+	if frame.CreatedBy {
+		function = "created by " + function
+	}
+
+	location := fmt.Sprintf("%s:%d", frame.File, frame.Line)
+
+	if isTestFrame(frame, testName) {
+		return styleHighlighted.Render(function) + " " + location
+	}
+
+	return function + " " + styleSecondary.Render(location)
+}
+
+// panicView renders the panic of a test. The stack trace is only shown while
+// the test is expanded
+func panicView(test *tests.TestNode, indent string, expanded bool) string {
+	p := test.Panic
+	if p == nil {
+		return ""
+	}
+
+	vb := viewbuilder.New()
+
+	message := p.Message
+	if !expanded {
+		message, _, _ = strings.Cut(message, "\n")
+	}
+
+	for i, line := range strings.Split(message, "\n") {
+		prefix := "       "
+		if i == 0 {
+			prefix = styleCross.Render("panic: ")
+		}
+
+		vb.Add(indent + "  " + prefix + line)
+		vb.AddLine()
+	}
+
+	if !expanded {
+		return vb.String()
+	}
+
+	for _, running := range p.RunningTests {
+		vb.Add(indent + "    " + styleTimedOut.Render("running: ") + running.Name + " " + styleSecondary.Render(formatDuration(running.Elapsed)))
+		vb.AddLine()
+	}
+
+	// goroutines with identical stacks are grouped so that a deadlock in a
+	// large dump is easy to spot
+	for _, group := range outputparse.GroupGoroutines(p.Goroutines) {
+		vb.Add(indent + "    " + styleSecondary.Render(goroutineGroupLabel(group)))
+		vb.AddLine()
+
+		for _, frame := range group.Frames {
+			vb.Add(indent + "      " + stackFrameView(frame, test.Ref.Test))
+			vb.AddLine()
+		}
+	}
+
+	return vb.String()
+}
+
+func goroutineGroupLabel(group outputparse.GoroutineGroup) string {
+	if len(group.IDs) == 1 {
+		return fmt.Sprintf("goroutine %d [%s]", group.IDs[0], group.State)
+	}
+
+	ids := make([]string, len(group.IDs))
+	for i, id := range group.IDs {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	return fmt.Sprintf("%d goroutines [%s]: %s", len(group.IDs), group.State, strings.Join(ids, ", "))
+}
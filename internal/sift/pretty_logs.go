@@ -34,6 +34,10 @@ func prettifyLogEntry(entry logparse.LogEntry, baseStyle lipgloss.Style) string
 
 	prettifiedLog := fmt.Sprintf("%s%s %s%s", timeFormatted, level, message, additionalFields)
 
+	for _, line := range entry.Continuation {
+		prettifiedLog += "\n" + baseStyle.Render(line)
+	}
+
 	return prettifiedLog
 }
 
@@ -0,0 +1,54 @@
+package sift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/internal/tests"
+)
+
+const progressBarWidth = 30
+
+type durationsLoadedMsg tests.Durations
+
+// loadDurations reads the durations of the previous runs in the background, as
+// the history may be large
+func (m *siftModel) loadDurations() tea.Cmd {
+	if m.history == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		durations, err := m.history.Durations()
+		if err != nil {
+			return nil
+		}
+
+		return durationsLoadedMsg(durations)
+	}
+}
+
+// progressView shows how many of the listed tests have finished, with an
+// estimate of the time remaining from the durations of previous runs
+func (m *siftModel) progressView(now time.Time) string {
+	progress := m.testManager.GetProgress(m.durations)
+	if progress.Total == 0 {
+		return ""
+	}
+
+	done := int(progress.Percent() * progressBarWidth)
+
+	s := lipgloss.NewStyle().Foreground(colorBlue).Render(strings.Repeat(glyphs.ProgressDone, done))
+	s += styleSecondary.Render(strings.Repeat(glyphs.ProgressTodo, progressBarWidth-done))
+	s += fmt.Sprintf(" %3.0f%% ", progress.Percent()*100)
+	s += styleSecondary.Render(fmt.Sprintf("%d/%d tests", progress.Done, progress.Total))
+
+	if eta, ok := progress.ETA(now.Sub(m.startTime)); ok && progress.Done < progress.Total {
+		s += styleSecondary.Render(" ETA " + formatDuration(eta))
+	}
+
+	return s
+}
@@ -0,0 +1,66 @@
+package sift
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/outputparse"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+// raceAccessSummary describes an access by where it happened in the code, eg.
+// read by goroutine 9 at foo_test.go:12
+func raceAccessSummary(access outputparse.RaceAccess) string {
+	summary := fmt.Sprintf("%s by %s", strings.ToLower(access.Kind), access.Goroutine)
+
+	if len(access.Frames) > 0 {
+		frame := access.Frames[0]
+		summary += fmt.Sprintf(" at %s:%d", filepath.Base(frame.File), frame.Line)
+	}
+
+	return summary
+}
+
+// raceView renders the data races detected in a test. The accesses and
+// where the goroutines were created are only shown while the test is expanded
+func raceView(test *tests.TestNode, indent string, expanded bool) string {
+	vb := viewbuilder.New()
+
+	for _, race := range test.Races {
+		summaries := make([]string, len(race.Accesses))
+		for i, access := range race.Accesses {
+			summaries[i] = raceAccessSummary(access)
+		}
+
+		vb.Add(indent + "  " + styleCross.Render("data race: ") + strings.Join(summaries, ", "))
+		vb.AddLine()
+
+		if !expanded {
+			continue
+		}
+
+		for _, access := range race.Accesses {
+			vb.Add(indent + "    " + styleSecondary.Render(fmt.Sprintf("%s at %s by %s", access.Kind, access.Address, access.Goroutine)))
+			vb.AddLine()
+
+			for _, frame := range access.Frames {
+				vb.Add(indent + "      " + stackFrameView(frame, test.Ref.Test))
+				vb.AddLine()
+			}
+		}
+
+		for _, goroutine := range race.Goroutines {
+			vb.Add(indent + "    " + styleSecondary.Render(fmt.Sprintf("goroutine %d (%s) created at", goroutine.ID, goroutine.State)))
+			vb.AddLine()
+
+			for _, frame := range goroutine.Frames {
+				vb.Add(indent + "      " + stackFrameView(frame, test.Ref.Test))
+				vb.AddLine()
+			}
+		}
+	}
+
+	return vb.String()
+}
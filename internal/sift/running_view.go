@@ -0,0 +1,87 @@
+package sift
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+const (
+	// maxRunningTests is the most running tests listed in the footer
+	maxRunningTests = 5
+
+	// hangCheckInterval is how often the running tests are checked for hangs
+	hangCheckInterval = time.Second
+)
+
+type hangCheckMsg time.Time
+
+func hangCheck() tea.Cmd {
+	return tea.Tick(hangCheckInterval, func(t time.Time) tea.Msg {
+		return hangCheckMsg(t)
+	})
+}
+
+// ringBell rings the terminal bell. It's written to stderr so it isn't
+// interleaved with the frames rendered to stdout
+func ringBell() tea.Msg {
+	_, _ = os.Stderr.WriteString("\a")
+	return nil
+}
+
+// checkHungTests flags the tests which have been running for longer than the
+// hang threshold, ringing the bell the first time each test is flagged
+func (m *siftModel) checkHungTests(now time.Time) tea.Cmd {
+	newlyHung := false
+	for _, test := range m.testManager.GetHungTests(m.opts.HangThreshold, now) {
+		if !m.hungTests[test.Ref] {
+			m.hungTests[test.Ref] = true
+			newlyHung = true
+		}
+	}
+
+	if newlyHung && m.opts.Bell {
+		return ringBell
+	}
+
+	return nil
+}
+
+// runningView lists the tests which are running with how long they have been
+// running for, the longest first so a hung test stands out
+func (m *siftModel) runningView(now time.Time) string {
+	running := m.testManager.GetRunningTests()
+	if len(running) == 0 {
+		return ""
+	}
+
+	vb := viewbuilder.New()
+	vb.Add(styleSecondary.Render(fmt.Sprintf("Running (%d)", len(running))))
+
+	for _, test := range running[:min(len(running), maxRunningTests)] {
+		elapsed := test.RunningElapsed(now)
+
+		vb.AddLine()
+		vb.Add(fmt.Sprintf("%s %s %s %s", m.getStatusIcon(test.Status), styleSecondary.Render(test.Ref.Package), test.Ref.Test, styleSecondary.Render(formatDuration(elapsed))))
+
+		if m.opts.HangThreshold > 0 && elapsed >= m.opts.HangThreshold {
+			vb.Add(" " + styleTimedOut.Render(glyphs.TimedOut+" possibly hung"))
+		}
+	}
+
+	if len(running) > maxRunningTests {
+		vb.AddLine()
+		vb.Add(styleSecondary.Render(fmt.Sprintf("+%d more", len(running)-maxRunningTests)))
+	}
+
+	return vb.String()
+}
+
+// isHung checks if the test has been flagged as possibly hung
+func (m *siftModel) isHung(test *tests.TestNode) bool {
+	return test.Status == tests.StatusRunning && m.hungTests[test.Ref]
+}
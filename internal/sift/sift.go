@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/timtatt/sift/internal/history"
 	"github.com/timtatt/sift/internal/tests"
 	"golang.org/x/sync/errgroup"
 )
@@ -21,7 +23,18 @@ type sift struct {
 }
 
 func (s *sift) ScanStdin() error {
-	scanner := bufio.NewScanner(os.Stdin)
+	if err := scanTestOutput(os.Stdin, s.model.testManager); err != nil {
+		return err
+	}
+
+	s.model.endTime = time.Now()
+
+	return nil
+}
+
+// scanTestOutput reads the output of `go test -json` line by line into the test manager
+func scanTestOutput(r io.Reader, testManager *tests.TestManager) error {
+	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
 		var line tests.TestOutputLine
@@ -32,15 +45,13 @@ func (s *sift) ScanStdin() error {
 			return errors.New("unable to parse json input. ensure to use the `-json` flag when running go tests")
 		}
 
-		s.model.testManager.AddTestOutput(line)
+		testManager.AddTestOutput(line)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to scan stdin: %w", err)
+		return fmt.Errorf("failed to scan input: %w", err)
 	}
 
-	s.model.endTime = time.Now()
-
 	return nil
 }
 
@@ -65,6 +76,67 @@ type SiftOptions struct {
 	Debug          bool
 	NonInteractive bool
 	PrettifyLogs   bool
+	Theme          string
+	ASCII          bool
+
+	// HangThreshold is how long a test can run before it's flagged as possibly
+	// hung, 0 disables the check
+	HangThreshold time.Duration
+
+	// Bell rings the terminal bell when a test is flagged as possibly hung
+	Bell bool
+
+	// NoHistory disables recording the run in the history, along with the
+	// estimate of the time remaining which is read from it
+	NoHistory bool
+
+	// HistoryRetention is how many runs are kept in the history, 0 keeps
+	// every run
+	HistoryRetention int
+
+	// GoTestArgs are the packages and flags to run `go test` with. the output
+	// is read from stdin when there are none
+	GoTestArgs []string
+}
+
+// historyStore opens the history of previous runs, or nil when the history
+// is disabled
+func historyStore(opts SiftOptions) *history.Store {
+	if opts.NoHistory {
+		return nil
+	}
+
+	dir, err := history.DefaultDir()
+	if err != nil {
+		return nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+
+	return history.NewStore(dir, history.FindProject(wd), opts.HistoryRetention)
+}
+
+// recordRun saves the results of the run to the history. it's best effort, as
+// a failure to save shouldn't fail the tests
+func recordRun(ctx context.Context, store *history.Store, m *siftModel) {
+	if store == nil || m.startTime.IsZero() {
+		return
+	}
+
+	run := history.NewRun(m.testManager, m.startTime, m.endTime)
+	if len(run.Tests) == 0 {
+		return
+	}
+
+	run.Args = m.opts.GoTestArgs
+	run.AddGitInfo(ctx)
+
+	if err := store.Save(run); err != nil {
+		slog.DebugContext(ctx, "failed to save run to history", "error", err)
+	}
 }
 
 func initLogging() error {
@@ -92,6 +164,14 @@ func Run(ctx context.Context, opts SiftOptions) error {
 		slog.DebugContext(ctx, "starting sift", "options", opts)
 	}
 
+	if opts.ASCII {
+		useASCII()
+	}
+
+	if err := applyTheme(opts.Theme); err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -101,6 +181,9 @@ func Run(ctx context.Context, opts SiftOptions) error {
 
 	m := NewSiftModel(opts)
 
+	store := historyStore(opts)
+	m.history = store
+
 	programOpts := []tea.ProgramOption{
 		tea.WithFPS(fps),
 		tea.WithContext(ctx),
@@ -118,6 +201,10 @@ func Run(ctx context.Context, opts SiftOptions) error {
 	}
 
 	g.Go(func() error {
+		if len(opts.GoTestArgs) > 0 {
+			return sift.RunGoTest(ctx, opts.GoTestArgs)
+		}
+
 		if err := sift.ScanStdin(); err != nil {
 			return err
 		}
@@ -140,5 +227,12 @@ func Run(ctx context.Context, opts SiftOptions) error {
 		return nil
 	})
 
-	return g.Wait()
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// the context has been cancelled by the program exiting
+	recordRun(context.WithoutCancel(ctx), store, m)
+
+	return nil
 }
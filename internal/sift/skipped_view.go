@@ -0,0 +1,53 @@
+package sift
+
+import (
+	"fmt"
+
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+// skipReasonLabel marks the reason as the last log before the test was
+// skipped, as it can't be told apart from a log followed by t.SkipNow
+const skipReasonLabel = "last log: "
+
+// getSkipReason shows why the test was likely skipped after its name
+func getSkipReason(test *tests.TestNode) string {
+	if test.Status != tests.StatusSkipped || test.SkipReason == "" {
+		return ""
+	}
+
+	return " " + styleSecondary.Render(skipReasonLabel) + styleSkip.UnsetBold().Italic(true).Render(test.SkipReason)
+}
+
+// skippedView lists the skipped tests of every package grouped by the last log
+// before they were skipped
+func (m *siftModel) skippedView() string {
+	groups := m.testManager.GetSkipGroups()
+	if len(groups) == 0 {
+		return styleSecondary.Render("No tests were skipped")
+	}
+
+	vb := viewbuilder.New()
+
+	for i, group := range groups {
+		if i > 0 {
+			vb.AddLine()
+		}
+
+		reason := styleSecondary.Render(skipReasonLabel) + group.Reason
+		if group.Reason == "" {
+			reason = "no reason given"
+		}
+
+		vb.Add(styleSkip.Render(glyphs.Skip+" ") + reason + styleSecondary.Render(fmt.Sprintf(" (%d)", len(group.Tests))))
+		vb.AddLine()
+
+		for _, ref := range group.Tests {
+			vb.Add("  " + styleSecondary.Render(ref.Package+" ") + ref.Test)
+			vb.AddLine()
+		}
+	}
+
+	return vb.String()
+}
@@ -0,0 +1,211 @@
+package sift
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+const (
+	// previewContext is the number of lines shown either side of the line in the preview
+	previewContext = 5
+
+	// minPreviewViewportHeight is the fewest lines of tests to keep when showing the preview
+	minPreviewViewportHeight = 10
+)
+
+type sourceKey struct {
+	pkg  string
+	file string
+}
+
+// sourceFile is a file which has been loaded for the preview, with each line
+// already highlighted
+type sourceFile struct {
+	path  string
+	lines []string
+	err   error
+}
+
+type sourceLoadedMsg struct {
+	key    sourceKey
+	source sourceFile
+}
+
+// getTokenStyle colors the tokens of go source
+func getTokenStyle(tok token.Token) (lipgloss.Style, bool) {
+	switch {
+	case tok == token.COMMENT:
+		return styleSecondary, true
+	case tok == token.STRING || tok == token.CHAR:
+		return lipgloss.NewStyle().Foreground(colorMutedOrange), true
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return lipgloss.NewStyle().Foreground(colorOrange), true
+	case tok.IsKeyword():
+		return lipgloss.NewStyle().Foreground(colorMutedBlue), true
+	default:
+		return lipgloss.Style{}, false
+	}
+}
+
+// highlightGo splits the source into lines with the tokens colored. The whole
+// file is scanned at once, as comments and raw strings can span lines
+func highlightGo(src []byte) []string {
+	var (
+		lines []string
+		line  strings.Builder
+	)
+
+	write := func(text string, style lipgloss.Style, styled bool) {
+		for i, part := range strings.Split(text, "\n") {
+			if i > 0 {
+				lines = append(lines, line.String())
+				line.Reset()
+			}
+
+			if styled && part != "" {
+				part = style.Render(part)
+			}
+			line.WriteString(part)
+		}
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	offset := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		// skip the semicolons inserted at the end of lines
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		start := file.Offset(pos)
+		end := start + len(lit)
+		if lit == "" {
+			end = start + len(tok.String())
+		}
+		end = min(end, len(src))
+
+		if start < offset {
+			continue
+		}
+
+		write(string(src[offset:start]), lipgloss.Style{}, false)
+
+		style, styled := getTokenStyle(tok)
+		write(string(src[start:end]), style, styled)
+
+		offset = end
+	}
+
+	write(string(src[offset:]), lipgloss.Style{}, false)
+	lines = append(lines, line.String())
+
+	return lines
+}
+
+// loadSource resolves and highlights the file in the background, as it may need
+// to run `go list`
+func (m *siftModel) loadSource(key sourceKey) tea.Cmd {
+	return func() tea.Msg {
+		path, err := m.packageDirs.resolve(key.pkg, key.file)
+		if err != nil {
+			return sourceLoadedMsg{key: key, source: sourceFile{err: err}}
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return sourceLoadedMsg{key: key, source: sourceFile{path: path, err: err}}
+		}
+
+		return sourceLoadedMsg{key: key, source: sourceFile{path: path, lines: highlightGo(src)}}
+	}
+}
+
+// cursorLocation finds the location of the log under the cursor
+func (m *siftModel) cursorLocation() (sourceKey, int, bool) {
+	_, log, ok := m.cursorLog()
+	if !ok {
+		return sourceKey{}, 0, false
+	}
+
+	file, line, ok := logLocation(log)
+	if !ok {
+		return sourceKey{}, 0, false
+	}
+
+	test := m.testManager.GetTest(m.cursor.test)
+
+	return sourceKey{pkg: test.Ref.Package, file: file}, line, true
+}
+
+// loadPreview starts loading the source for the log under the cursor, unless
+// it has already been loaded
+func (m *siftModel) loadPreview() tea.Cmd {
+	key, _, ok := m.cursorLocation()
+	if !ok {
+		return nil
+	}
+
+	if _, ok := m.sources[key]; ok {
+		return nil
+	}
+
+	// stops the file being loaded again while it is loading
+	m.sources[key] = nil
+
+	return m.loadSource(key)
+}
+
+// previewView shows the source around the location of the log under the
+// cursor, with the line itself marked
+func (m *siftModel) previewView() string {
+	key, line, ok := m.cursorLocation()
+	if !ok {
+		return ""
+	}
+
+	source := m.sources[key]
+	if source == nil || source.err != nil || line > len(source.lines) {
+		return ""
+	}
+
+	// long lines are cut off rather than wrapped so the height of the preview is fixed
+	truncate := lipgloss.NewStyle().MaxWidth(m.viewport.Width)
+
+	vb := viewbuilder.New()
+	vb.Add(styleSecondary.Render(fmt.Sprintf("%s:%d", filepath.Base(source.path), line)))
+
+	first := max(1, line-previewContext)
+	last := min(len(source.lines), line+previewContext)
+	width := len(fmt.Sprint(last))
+
+	for lineNo := first; lineNo <= last; lineNo++ {
+		vb.AddLine()
+
+		gutter := styleSecondary.Render(fmt.Sprintf("  %*d %s ", width, lineNo, glyphs.TreeBar))
+		if lineNo == line {
+			gutter = styleCross.Render(fmt.Sprintf("> %*d", width, lineNo)) + styleSecondary.Render(" "+glyphs.TreeBar+" ")
+		}
+
+		vb.Add(truncate.Render(gutter + source.lines[lineNo-1]))
+	}
+
+	return vb.String()
+}
@@ -1,89 +1,206 @@
 package sift
 
 import (
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
-var (
-	colorGreen = lipgloss.AdaptiveColor{
-		Light: "#2D7F1E",
-		Dark:  "#5FD700",
-	}
-	colorRed = lipgloss.AdaptiveColor{
-		Light: "#C41E3A",
-		Dark:  "#FF0000",
-	}
-	colorMutedRed = lipgloss.AdaptiveColor{
-		Light: "#A04040",
-		Dark:  "#D25D5D",
-	}
-	colorOrange = lipgloss.AdaptiveColor{
-		Light: "#D97009",
-		Dark:  "#FFAF00",
-	}
-	colorMutedOrange = lipgloss.AdaptiveColor{
-		Light: "#A65D30",
-		Dark:  "#D27E5D",
-	}
-	colorBlue = lipgloss.AdaptiveColor{
-		Light: "#004080",
-		Dark:  "#005FFF",
-	}
-	colorMutedBlue = lipgloss.AdaptiveColor{
-		Light: "#4A90E2",
-		Dark:  "#5B9BD5",
-	}
-	colorHighlight = lipgloss.AdaptiveColor{
-		Light: "#E0E8F0",
-		Dark:  "#2B57A3",
-	}
-	colorGrey = lipgloss.AdaptiveColor{
-		Light: "#6C6C6C",
-		Dark:  "#808080",
-	}
+type theme struct {
+	Green       lipgloss.TerminalColor
+	Red         lipgloss.TerminalColor
+	MutedRed    lipgloss.TerminalColor
+	Orange      lipgloss.TerminalColor
+	MutedOrange lipgloss.TerminalColor
+	Blue        lipgloss.TerminalColor
+	MutedBlue   lipgloss.TerminalColor
+	Highlight   lipgloss.TerminalColor
+	Grey        lipgloss.TerminalColor
+	Log         lipgloss.TerminalColor
 
-	styleIcon = lipgloss.NewStyle().Bold(true)
+	// colors of the running spinner, one per frame
+	Pulse []lipgloss.TerminalColor
+}
+
+var themes = map[string]theme{
+	"default": {
+		Green:       lipgloss.AdaptiveColor{Light: "#2D7F1E", Dark: "#5FD700"},
+		Red:         lipgloss.AdaptiveColor{Light: "#C41E3A", Dark: "#FF0000"},
+		MutedRed:    lipgloss.AdaptiveColor{Light: "#A04040", Dark: "#D25D5D"},
+		Orange:      lipgloss.AdaptiveColor{Light: "#D97009", Dark: "#FFAF00"},
+		MutedOrange: lipgloss.AdaptiveColor{Light: "#A65D30", Dark: "#D27E5D"},
+		Blue:        lipgloss.AdaptiveColor{Light: "#004080", Dark: "#005FFF"},
+		MutedBlue:   lipgloss.AdaptiveColor{Light: "#4A90E2", Dark: "#5B9BD5"},
+		Highlight:   lipgloss.AdaptiveColor{Light: "#E0E8F0", Dark: "#2B57A3"},
+		Grey:        lipgloss.AdaptiveColor{Light: "#6C6C6C", Dark: "#808080"},
+		Log:         lipgloss.AdaptiveColor{Light: "#4A4A4A", Dark: "#B2B2B2"},
+		Pulse: []lipgloss.TerminalColor{
+			lipgloss.AdaptiveColor{Light: "#808080", Dark: "#4D4D4D"},
+			lipgloss.AdaptiveColor{Light: "#A65D30", Dark: "#806040"},
+			lipgloss.AdaptiveColor{Light: "#C97009", Dark: "#B38030"},
+			lipgloss.AdaptiveColor{Light: "#D97009", Dark: "#FFAF00"},
+			lipgloss.AdaptiveColor{Light: "#C97009", Dark: "#B38030"},
+			lipgloss.AdaptiveColor{Light: "#A65D30", Dark: "#806040"},
+		},
+	},
+	"high-contrast": {
+		Green:       lipgloss.AdaptiveColor{Light: "#005F00", Dark: "#00FF00"},
+		Red:         lipgloss.AdaptiveColor{Light: "#AF0000", Dark: "#FF5F5F"},
+		MutedRed:    lipgloss.AdaptiveColor{Light: "#AF0000", Dark: "#FF5F5F"},
+		Orange:      lipgloss.AdaptiveColor{Light: "#875F00", Dark: "#FFFF00"},
+		MutedOrange: lipgloss.AdaptiveColor{Light: "#875F00", Dark: "#FFD700"},
+		Blue:        lipgloss.AdaptiveColor{Light: "#00005F", Dark: "#0000FF"},
+		MutedBlue:   lipgloss.AdaptiveColor{Light: "#0000AF", Dark: "#5FD7FF"},
+		Highlight:   lipgloss.AdaptiveColor{Light: "#FFFF00", Dark: "#5F00AF"},
+		Grey:        lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+		Log:         lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Pulse: []lipgloss.TerminalColor{
+			lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+			lipgloss.AdaptiveColor{Light: "#875F00", Dark: "#FFFF00"},
+		},
+	},
+	"no-color": {
+		Green:       lipgloss.NoColor{},
+		Red:         lipgloss.NoColor{},
+		MutedRed:    lipgloss.NoColor{},
+		Orange:      lipgloss.NoColor{},
+		MutedOrange: lipgloss.NoColor{},
+		Blue:        lipgloss.NoColor{},
+		MutedBlue:   lipgloss.NoColor{},
+		Highlight:   lipgloss.NoColor{},
+		Grey:        lipgloss.NoColor{},
+		Log:         lipgloss.NoColor{},
+		Pulse:       []lipgloss.TerminalColor{lipgloss.NoColor{}},
+	},
+}
+
+var (
+	currentTheme theme
 
-	styleTick = styleIcon.
-			Foreground(colorGreen)
+	colorGreen       lipgloss.TerminalColor
+	colorRed         lipgloss.TerminalColor
+	colorMutedRed    lipgloss.TerminalColor
+	colorOrange      lipgloss.TerminalColor
+	colorMutedOrange lipgloss.TerminalColor
+	colorBlue        lipgloss.TerminalColor
+	colorMutedBlue   lipgloss.TerminalColor
+	colorHighlight   lipgloss.TerminalColor
+	colorGrey        lipgloss.TerminalColor
 
-	styleCross = styleIcon.
-			Foreground(colorRed)
+	styleIcon = lipgloss.NewStyle().Bold(true)
 
-	styleProgress = styleIcon.
-			Foreground(colorOrange)
+	styleTick     lipgloss.Style
+	styleCross    lipgloss.Style
+	styleProgress lipgloss.Style
+	styleSkip     lipgloss.Style
+	styleAborted  lipgloss.Style
+	styleTimedOut lipgloss.Style
+	styleVetError lipgloss.Style
 
-	styleSkip = styleIcon.
-			Foreground(colorMutedBlue)
+	styleSecondary   lipgloss.Style
+	styleHighlighted lipgloss.Style
+	styleBadge       lipgloss.Style
 
-	styleSecondary   = lipgloss.NewStyle().Foreground(colorGrey)
-	styleHighlighted = lipgloss.NewStyle().Background(colorHighlight)
+	styleLog lipgloss.Style
 
-	styleLog = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
-		Light: "#4A4A4A",
-		Dark:  "#B2B2B2",
-	})
+	styleDiffRemoved lipgloss.Style
+	styleDiffAdded   lipgloss.Style
+	styleDiffHunk    lipgloss.Style
 
-	styleHeader = lipgloss.NewStyle().Background(colorBlue).Bold(true).PaddingLeft(1).PaddingRight(1)
+	styleHeader lipgloss.Style
 
 	styleBody = lipgloss.NewStyle().Padding(1)
 
 	styleOutcome     = lipgloss.NewStyle().PaddingLeft(1).PaddingRight(1)
-	styleOutcomePass = styleOutcome.Background(colorGreen)
-	styleOutcomeFail = styleOutcome.Background(colorRed)
+	styleOutcomePass lipgloss.Style
+	styleOutcomeFail lipgloss.Style
+
+	CenterDotPulse spinner.Spinner
+)
+
+func init() {
+	setTheme(themes["default"])
+}
+
+// applyTheme switches all styles over to the named theme
+func applyTheme(name string) error {
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q", name)
+	}
+
+	setTheme(t)
+
+	return nil
+}
+
+// IgnoreNoColor renders colors even when NO_COLOR is set, which lipgloss
+// otherwise respects by dropping every color
+func IgnoreNoColor() {
+	lipgloss.SetColorProfile(termenv.NewOutput(os.Stdout).ColorProfile())
+}
+
+func setTheme(t theme) {
+	currentTheme = t
+
+	colorGreen = t.Green
+	colorRed = t.Red
+	colorMutedRed = t.MutedRed
+	colorOrange = t.Orange
+	colorMutedOrange = t.MutedOrange
+	colorBlue = t.Blue
+	colorMutedBlue = t.MutedBlue
+	colorHighlight = t.Highlight
+	colorGrey = t.Grey
+
+	styleTick = styleIcon.Foreground(colorGreen)
+	styleCross = styleIcon.Foreground(colorRed)
+	styleProgress = styleIcon.Foreground(colorOrange)
+	styleSkip = styleIcon.Foreground(colorMutedBlue)
+	styleAborted = styleIcon.Foreground(colorMutedRed)
+	styleTimedOut = styleIcon.Foreground(colorMutedOrange)
+	styleVetError = styleIcon.Foreground(colorOrange)
+
+	styleSecondary = lipgloss.NewStyle().Foreground(colorGrey)
+	styleHighlighted = withBackground(lipgloss.NewStyle(), colorHighlight)
+	styleBadge = lipgloss.NewStyle().Foreground(colorMutedBlue)
+
+	styleLog = lipgloss.NewStyle().Foreground(t.Log)
+
+	styleDiffRemoved = lipgloss.NewStyle().Foreground(colorRed)
+	styleDiffAdded = lipgloss.NewStyle().Foreground(colorGreen)
+	styleDiffHunk = lipgloss.NewStyle().Foreground(colorMutedBlue)
+
+	styleHeader = withBackground(lipgloss.NewStyle(), colorBlue).Bold(true).PaddingLeft(1).PaddingRight(1)
+
+	styleOutcomePass = withBackground(styleOutcome, colorGreen)
+	styleOutcomeFail = withBackground(styleOutcome, colorRed)
+
+	setPulse(t.Pulse)
+}
+
+// withBackground falls back to reversed text when the theme has no colors,
+// otherwise highlighted elements would become indistinguishable
+func withBackground(style lipgloss.Style, color lipgloss.TerminalColor) lipgloss.Style {
+	if _, ok := color.(lipgloss.NoColor); ok {
+		return style.Reverse(true)
+	}
+	return style.Background(color)
+}
+
+// setPulse builds the running spinner from the theme colors and the current glyphs
+func setPulse(colors []lipgloss.TerminalColor) {
+	frames := make([]string, len(colors))
+	for i, color := range colors {
+		frames[i] = lipgloss.NewStyle().Foreground(color).Render(glyphs.Pulse)
+	}
 
 	CenterDotPulse = spinner.Spinner{
-		Frames: []string{
-			lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#808080", Dark: "#4D4D4D"}).Render("∙"),
-			lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#A65D30", Dark: "#806040"}).Render("∙"),
-			lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#C97009", Dark: "#B38030"}).Render("∙"),
-			lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#D97009", Dark: "#FFAF00"}).Render("∙"),
-			lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#C97009", Dark: "#B38030"}).Render("∙"),
-			lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#A65D30", Dark: "#806040"}).Render("∙"),
-		},
-		FPS: time.Second / 10,
+		Frames: frames,
+		FPS:    time.Second / 10,
 	}
-)
+}
@@ -24,10 +24,18 @@ func (m *siftModel) summaryView(summary *tests.Summary) string {
 		s += styleCross.Bold(true).Render(fmt.Sprintf("%d failed ", ps.Failed))
 	}
 
+	if ps.BuildFailed > 0 {
+		s += styleCross.Bold(true).Render(fmt.Sprintf("%d build failed ", ps.BuildFailed))
+	}
+
+	if ps.VetFailed > 0 {
+		s += styleVetError.Bold(true).Render(fmt.Sprintf("%d vet failed ", ps.VetFailed))
+	}
+
 	if ps.Running > 0 {
 		s += styleSecondary.Render(fmt.Sprintf("%d running ", ps.Running))
 	}
-	s += styleSecondary.Render(fmt.Sprintf("(%d)", ps.Passed+ps.Failed+ps.Running))
+	s += styleSecondary.Render(fmt.Sprintf("(%d)", ps.Passed+ps.Failed+ps.BuildFailed+ps.VetFailed+ps.Running))
 	s += "\n"
 
 	s += summaryLabel.Render("Tests")
@@ -43,13 +51,32 @@ func (m *siftModel) summaryView(summary *tests.Summary) string {
 
 	if total.Skipped > 0 {
 		s += styleSkip.Bold(true).Render(fmt.Sprintf("%d skipped ", total.Skipped))
+		if !m.opts.NonInteractive {
+			s += styleSecondary.Render(fmt.Sprintf("[%s] ", keys.ShowSkipped.Help().Key))
+		}
+	}
+
+	if total.Raced > 0 {
+		s += styleCross.Bold(true).Render(fmt.Sprintf("%d raced ", total.Raced))
+	}
+
+	if total.TimedOut > 0 {
+		s += styleTimedOut.Render(fmt.Sprintf("%d timed out ", total.TimedOut))
+	}
+
+	if total.Aborted > 0 {
+		s += styleAborted.Render(fmt.Sprintf("%d aborted ", total.Aborted))
 	}
 
 	if total.Running > 0 {
 		s += styleSecondary.Render(fmt.Sprintf("%d running ", total.Running))
 	}
 
-	s += styleSecondary.Render(fmt.Sprintf("(%d)", total.Passed+total.Failed+total.Running))
+	if total.Queued > 0 {
+		s += styleSecondary.Render(fmt.Sprintf("%d queued ", total.Queued))
+	}
+
+	s += styleSecondary.Render(fmt.Sprintf("(%d)", total.Passed+total.Failed+total.Raced+total.TimedOut+total.Aborted+total.Running+total.Queued))
 	s += "\n"
 
 	s += summaryLabel.Render("Start At")
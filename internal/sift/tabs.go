@@ -0,0 +1,96 @@
+package sift
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type tab int
+
+const (
+	tabTests tab = iota
+	tabTimeline
+	tabBenchmarks
+	tabSkipped
+	tabCount
+)
+
+func (t tab) String() string {
+	switch t {
+	case tabTests:
+		return "Tests"
+	case tabTimeline:
+		return "Timeline"
+	case tabBenchmarks:
+		return "Benchmarks"
+	case tabSkipped:
+		return "Skipped"
+	default:
+		return ""
+	}
+}
+
+func (m *siftModel) SwitchTab(forward bool) {
+	if forward {
+		m.tab = (m.tab + 1) % tabCount
+	} else {
+		m.tab = (m.tab + tabCount - 1) % tabCount
+	}
+
+	m.viewport.GotoTop()
+}
+
+// ToggleSkippedTab jumps between the skipped tests and the tests
+func (m *siftModel) ToggleSkippedTab() {
+	if m.tab == tabSkipped {
+		m.tab = tabTests
+	} else {
+		m.tab = tabSkipped
+	}
+
+	m.viewport.GotoTop()
+}
+
+func (m *siftModel) tabBarView() string {
+	var s string
+	for t := range tabCount {
+		if t == m.tab {
+			s += styleHighlighted.Bold(true).Render(" " + t.String() + " ")
+		} else {
+			s += styleSecondary.Render(" " + t.String() + " ")
+		}
+	}
+	return s
+}
+
+// tabView renders the content of the current tab, other than the tests tab
+func (m *siftModel) tabView() string {
+	switch m.tab {
+	case tabTimeline:
+		return m.timelineView()
+	case tabBenchmarks:
+		return m.benchmarksView()
+	case tabSkipped:
+		return m.skippedView()
+	default:
+		return ""
+	}
+}
+
+// updateTab handles the keys for tabs which don't have a cursor and can
+// only be scrolled
+func (m *siftModel) updateTab(command keyCommand) tea.Cmd {
+	switch {
+	case command.Matches(keys.Up):
+		m.viewport.ScrollUp(command.Count())
+	case command.Matches(keys.Down):
+		m.viewport.ScrollDown(command.Count())
+	case m.tab == tabBenchmarks && command.Matches(keys.SortBenchmarks):
+		m.benchmarkSort = (m.benchmarkSort + 1) % benchmarkSortCount
+	case command.Matches(keys.Help):
+		m.help.ShowAll = !m.help.ShowAll
+	case command.Matches(keys.Quit):
+		return m.quit()
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package sift
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ThemeColor is a color of a theme defined in the config. it's either a single
+// color, eg. "#5FD700" or "10", or an object with a color for light and dark
+// terminals, eg. {"light": "#2D7F1E", "dark": "#5FD700"}
+type ThemeColor struct {
+	Light string `json:"light"`
+	Dark  string `json:"dark"`
+}
+
+func (c *ThemeColor) UnmarshalJSON(data []byte) error {
+	var color string
+	if err := json.Unmarshal(data, &color); err == nil {
+		c.Light, c.Dark = color, color
+		return nil
+	}
+
+	type themeColor ThemeColor
+	return json.Unmarshal(data, (*themeColor)(c))
+}
+
+func (c *ThemeColor) terminalColor() lipgloss.TerminalColor {
+	return lipgloss.AdaptiveColor{Light: c.Light, Dark: c.Dark}
+}
+
+// ThemeConfig defines a theme in the config. colors which aren't set are
+// taken from the base theme, one of the built in themes, which is the default
+// theme when not set
+type ThemeConfig struct {
+	Base string `json:"base"`
+
+	Green       *ThemeColor `json:"green"`
+	Red         *ThemeColor `json:"red"`
+	MutedRed    *ThemeColor `json:"mutedRed"`
+	Orange      *ThemeColor `json:"orange"`
+	MutedOrange *ThemeColor `json:"mutedOrange"`
+	Blue        *ThemeColor `json:"blue"`
+	MutedBlue   *ThemeColor `json:"mutedBlue"`
+	Highlight   *ThemeColor `json:"highlight"`
+	Grey        *ThemeColor `json:"grey"`
+	Log         *ThemeColor `json:"log"`
+
+	// Pulse are the colors of the running spinner, one per frame
+	Pulse []ThemeColor `json:"pulse"`
+}
+
+// RegisterThemes adds the themes defined in the config, so they can be chosen
+// by name alongside the built in themes
+func RegisterThemes(configs map[string]ThemeConfig) error {
+	for name, config := range configs {
+		baseName := config.Base
+		if baseName == "" {
+			baseName = "default"
+		}
+
+		base, ok := themes[baseName]
+		if _, custom := configs[baseName]; !ok || custom {
+			return fmt.Errorf("theme %q: unknown base theme %q", name, baseName)
+		}
+
+		t := base
+		for _, color := range []struct {
+			target *lipgloss.TerminalColor
+			color  *ThemeColor
+		}{
+			{&t.Green, config.Green},
+			{&t.Red, config.Red},
+			{&t.MutedRed, config.MutedRed},
+			{&t.Orange, config.Orange},
+			{&t.MutedOrange, config.MutedOrange},
+			{&t.Blue, config.Blue},
+			{&t.MutedBlue, config.MutedBlue},
+			{&t.Highlight, config.Highlight},
+			{&t.Grey, config.Grey},
+			{&t.Log, config.Log},
+		} {
+			if color.color != nil {
+				*color.target = color.color.terminalColor()
+			}
+		}
+
+		if len(config.Pulse) > 0 {
+			t.Pulse = nil
+			for _, color := range config.Pulse {
+				t.Pulse = append(t.Pulse, color.terminalColor())
+			}
+		}
+
+		themes[name] = t
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package sift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/viewbuilder"
+)
+
+const maxTimelineLabelWidth = 40
+
+type timelineCell int
+
+const (
+	cellEmpty timelineCell = iota
+	cellPaused
+	cellRunning
+)
+
+// timelineCells maps the spans of a test onto a row of cells, where the row
+// starts at 'start' and covers a duration of 'total'. Open spans are treated
+// as running until 'now'
+func timelineCells(spans []tests.TestSpan, start time.Time, total time.Duration, width int, now time.Time) []timelineCell {
+	cells := make([]timelineCell, width)
+	if len(spans) == 0 || total <= 0 || width <= 0 {
+		return cells
+	}
+
+	col := func(t time.Time) int {
+		c := int(float64(t.Sub(start)) / float64(total) * float64(width))
+		return max(0, min(c, width-1))
+	}
+
+	spanEnd := func(span tests.TestSpan) time.Time {
+		if span.End.IsZero() {
+			return now
+		}
+		return span.End
+	}
+
+	// between the first start and the last end, the test is paused unless a span covers it
+	for i := col(spans[0].Start); i <= col(spanEnd(spans[len(spans)-1])); i++ {
+		cells[i] = cellPaused
+	}
+
+	for _, span := range spans {
+		for i := col(span.Start); i <= col(spanEnd(span)); i++ {
+			cells[i] = cellRunning
+		}
+	}
+
+	return cells
+}
+
+// timelineRange finds the earliest start and latest end across all tests
+func (m *siftModel) timelineRange() (time.Time, time.Time) {
+	var start, end time.Time
+	running := false
+
+	for _, test := range m.testManager.GetTests {
+		for _, span := range test.Spans {
+			if start.IsZero() || span.Start.Before(start) {
+				start = span.Start
+			}
+			if span.Start.After(end) {
+				end = span.Start
+			}
+			if span.End.IsZero() {
+				running = true
+			} else if span.End.After(end) {
+				end = span.End
+			}
+		}
+	}
+
+	if running && m.endTime.IsZero() {
+		end = time.Now()
+	}
+
+	return start, end
+}
+
+func getTimelineStyle(status tests.TestStatus) lipgloss.Style {
+	switch status {
+	case tests.StatusPassed:
+		return styleTick
+	case tests.StatusFailed:
+		return styleCross
+	case tests.StatusSkipped:
+		return styleSkip
+	case tests.StatusAborted:
+		return styleAborted
+	case tests.StatusTimedOut:
+		return styleTimedOut
+	default:
+		return styleProgress
+	}
+}
+
+func getTimelineLabel(testName string) string {
+	label := strings.Repeat("  ", getIndentLevel(testName)) + getDisplayName(testName)
+
+	runes := []rune(label)
+	if len(runes) > maxTimelineLabelWidth {
+		return string(runes[:maxTimelineLabelWidth-len([]rune(glyphs.Ellipsis))]) + glyphs.Ellipsis
+	}
+
+	return label
+}
+
+func (m *siftModel) timelineView() string {
+	start, end := m.timelineRange()
+	total := end.Sub(start)
+
+	if start.IsZero() || total <= 0 {
+		return styleSecondary.Render("No timing information available yet")
+	}
+
+	labelWidth := 0
+	for _, test := range m.testManager.GetTests {
+		if len(test.Spans) > 0 && m.isTestVisible(test) {
+			labelWidth = max(labelWidth, len([]rune(getTimelineLabel(test.Ref.Test))))
+		}
+	}
+
+	// leave space for the duration at the end of each row
+	chartWidth := max(m.viewport.Width-2-labelWidth-10, 10)
+
+	vb := viewbuilder.New()
+
+	startLabel := "0s"
+	endLabel := formatDuration(total)
+	vb.Add(styleSecondary.Render(fmt.Sprintf(
+		"%-*s %s%*s",
+		labelWidth, "", startLabel, chartWidth-len(startLabel), endLabel,
+	)))
+	vb.AddLine()
+
+	var lastPackage string
+
+	for _, test := range m.testManager.GetTests {
+		if len(test.Spans) == 0 || !m.isTestVisible(test) {
+			continue
+		}
+
+		if test.Ref.Package != lastPackage {
+			vb.AddLine()
+			vb.Add(styleSecondary.Render(test.Ref.Package))
+			vb.AddLine()
+			lastPackage = test.Ref.Package
+		}
+
+		cells := timelineCells(test.Spans, start, total, chartWidth, end)
+
+		runStyle := getTimelineStyle(test.Status)
+
+		// render consecutive cells of the same kind together to reduce styling overhead
+		var bar strings.Builder
+		for i := 0; i < len(cells); {
+			j := i
+			for j < len(cells) && cells[j] == cells[i] {
+				j++
+			}
+
+			switch cells[i] {
+			case cellRunning:
+				bar.WriteString(runStyle.Render(strings.Repeat(glyphs.TimelineRun, j-i)))
+			case cellPaused:
+				bar.WriteString(styleSecondary.Render(strings.Repeat(glyphs.TimelinePause, j-i)))
+			default:
+				bar.WriteString(strings.Repeat(" ", j-i))
+			}
+
+			i = j
+		}
+
+		elapsed := ""
+		if test.Status.Done() {
+			elapsed = styleSecondary.Render(formatDuration(test.Elapsed))
+		}
+
+		vb.Add(fmt.Sprintf("%-*s %s %s", labelWidth, getTimelineLabel(test.Ref.Test), bar.String(), elapsed))
+		vb.AddLine()
+	}
+
+	return vb.String()
+}
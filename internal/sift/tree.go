@@ -0,0 +1,226 @@
+package sift
+
+import (
+	"github.com/timtatt/sift/internal/tests"
+)
+
+// moveCursorTo moves the cursor onto the test, keeping only it open in auto
+// toggle mode
+func (m *siftModel) moveCursorTo(index int) {
+	if m.autoToggleMode {
+		m.ToggleTest(m.cursor.test, false)
+		m.ToggleTest(index, true)
+	}
+
+	m.cursor.test = index
+	m.cursor.log = 0
+}
+
+// isSibling checks if both tests were run by the same parent. tests without a
+// parent are siblings of the other tests in their package
+func isSibling(a, b tests.TestReference) bool {
+	aParent, _ := a.Parent()
+	bParent, _ := b.Parent()
+
+	return a.Package == b.Package && a.Test != "" && b.Test != "" && aParent == bParent
+}
+
+// ParentTest moves the cursor to the test which ran the current subtest
+func (m *siftModel) ParentTest() {
+	test := m.testManager.GetTest(m.cursor.test)
+	if test == nil {
+		return
+	}
+
+	parent, ok := test.Ref.Parent()
+	if !ok {
+		return
+	}
+
+	for i := m.cursor.test - 1; i >= 0; i-- {
+		if t := m.testManager.GetTest(i); t != nil && t.Ref == parent && m.isTestVisible(t) {
+			m.moveCursorTo(i)
+			return
+		}
+	}
+}
+
+// FirstChild moves the cursor to the first subtest of the current test
+func (m *siftModel) FirstChild() {
+	test := m.testManager.GetTest(m.cursor.test)
+	if test == nil || test.Ref.Test == "" {
+		return
+	}
+
+	for i := m.cursor.test + 1; i < m.testManager.GetTestCount(); i++ {
+		t := m.testManager.GetTest(i)
+		if t == nil || !test.Ref.Contains(t.Ref) {
+			return
+		}
+
+		if parent, _ := t.Ref.Parent(); parent == test.Ref && m.isTestVisible(t) {
+			m.moveCursorTo(i)
+			return
+		}
+	}
+}
+
+// NextSibling moves the cursor to the next test at the same level, skipping
+// over the subtests of the current test
+func (m *siftModel) NextSibling() {
+	test := m.testManager.GetTest(m.cursor.test)
+	if test == nil {
+		return
+	}
+
+	for i := m.cursor.test + 1; i < m.testManager.GetTestCount(); i++ {
+		if t := m.testManager.GetTest(i); t != nil && isSibling(test.Ref, t.Ref) && m.isTestVisible(t) {
+			m.moveCursorTo(i)
+			return
+		}
+	}
+}
+
+// PrevSibling moves the cursor to the previous test at the same level
+func (m *siftModel) PrevSibling() {
+	test := m.testManager.GetTest(m.cursor.test)
+	if test == nil {
+		return
+	}
+
+	for i := m.cursor.test - 1; i >= 0; i-- {
+		if t := m.testManager.GetTest(i); t != nil && isSibling(test.Ref, t.Ref) && m.isTestVisible(t) {
+			m.moveCursorTo(i)
+			return
+		}
+	}
+}
+
+// ToggleLevel expands or collapses the current test along with its siblings
+func (m *siftModel) ToggleLevel(toggled bool) {
+	current := m.testManager.GetTest(m.cursor.test)
+	if current == nil {
+		return
+	}
+
+	for _, test := range m.testManager.GetTests {
+		if test.Ref != current.Ref && !isSibling(current.Ref, test.Ref) {
+			continue
+		}
+
+		// siblings which arrived since the last render have no state yet
+		ts, ok := m.testState[test.Ref]
+		if !ok {
+			ts = &testState{}
+			m.testState[test.Ref] = ts
+		}
+
+		ts.toggled = toggled
+	}
+
+	if !toggled {
+		m.cursor.log = 0
+	}
+}
+
+// ToggleZoom hides every test other than the subtree of the current test, or
+// shows them again when already zoomed
+func (m *siftModel) ToggleZoom() {
+	if m.zoom != nil {
+		m.ClearZoom()
+		return
+	}
+
+	test := m.testManager.GetTest(m.cursor.test)
+	if test == nil || test.Ref.Test == "" {
+		return
+	}
+
+	ref := test.Ref
+	m.zoom = &ref
+	m.viewport.GotoTop()
+}
+
+func (m *siftModel) ClearZoom() {
+	m.zoom = nil
+	m.ensureCursorVisible()
+}
+
+// GotoTest moves the cursor to the nth visible test, counting from 1. Without
+// a count it moves to the first test, or the last when toBottom is set
+func (m *siftModel) GotoTest(n int, toBottom bool) {
+	var visible []int
+	for i, test := range m.testManager.GetTests {
+		if m.isTestVisible(test) {
+			visible = append(visible, i)
+		}
+	}
+
+	if len(visible) == 0 {
+		return
+	}
+
+	idx := 0
+	if n > 0 {
+		idx = min(n, len(visible)) - 1
+	} else if toBottom {
+		idx = len(visible) - 1
+	}
+
+	m.moveCursorTo(visible[idx])
+}
+
+// GotoScreenLine moves the cursor to the test closest to the line of the
+// viewport, keeping the cursor within the screen. The cursor is moved once the
+// tests have been iterated, as moving it locks the tests again
+func (m *siftModel) GotoScreenLine(line int) {
+	top := m.viewport.YOffset
+	bottom := m.viewport.YOffset + m.viewport.Height - 1
+	target := top + line
+
+	best, bestDistance := -1, 0
+	for i, test := range m.testManager.GetTests {
+		// packages which failed to build have no line of their own
+		ts, ok := m.testState[test.Ref]
+		if !ok || test.Ref.Test == "" || !m.isTestVisible(test) || ts.viewportPos < top || ts.viewportPos > bottom {
+			continue
+		}
+
+		distance := abs(ts.viewportPos - target)
+		if best < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+
+	if best >= 0 {
+		m.moveCursorTo(best)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// JumpToMark moves the cursor to the test marked with the register
+func (m *siftModel) JumpToMark(register string) {
+	ref, ok := m.marks[register]
+	if !ok {
+		return
+	}
+
+	target := -1
+	for i, test := range m.testManager.GetTests {
+		if test.Ref == ref && m.isTestVisible(test) {
+			target = i
+			break
+		}
+	}
+
+	if target >= 0 {
+		m.moveCursorTo(target)
+	}
+}
@@ -1,22 +1,33 @@
 package sift
 
 import (
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/timtatt/sift/internal/history"
 	"github.com/timtatt/sift/internal/tests"
 	"github.com/timtatt/sift/pkg/helpview"
+	"github.com/timtatt/sift/pkg/logparse"
 )
 
 type testState struct {
 	toggled     bool
 	viewportPos int
+
+	// logPositions are the lines each log starts at, as logs can span
+	// multiple lines when wrapped or when they are a multi-line failure
+	logPositions []int
+
+	// foldedDiffs are the logs containing a diff which have been folded,
+	// keyed by the index of the log among all the logs of the test, as the
+	// position it's shown at changes with failures only
+	foldedDiffs map[int]bool
 }
 
 type viewMode int
@@ -35,14 +46,30 @@ type siftModel struct {
 	cursor *cursor
 
 	autoToggleMode bool
+	failuresOnly   bool
+
+	// zoom is the test whose subtree is the only one shown, when set
+	zoom *tests.TestReference
 
 	startTime time.Time
 	endTime   time.Time
 
-	ready     bool
-	started   bool
-	viewport  viewport.Model
-	keyBuffer []string
+	ready    bool
+	started  bool
+	viewport viewport.Model
+
+	keySequence *keySequence
+
+	// marks are the tests marked with m{a-z}, keyed by the register
+	marks map[string]tests.TestReference
+
+	// hungTests are the tests which have been flagged as possibly hung
+	hungTests map[tests.TestReference]bool
+
+	// durations are how long the tests took in the previous runs, loaded from
+	// the history in the background
+	durations tests.Durations
+	history   *history.Store
 
 	help *helpview.WrappingHelpView
 
@@ -53,6 +80,18 @@ type siftModel struct {
 	runningSpinner spinner.Model
 
 	mode viewMode
+	tab  tab
+
+	benchmarkSort benchmarkSort
+
+	packageDirs *packageDirs
+
+	// sources are the files loaded for the source preview. a nil file is still
+	// loading. they're cleared when the editor closes, as the files may have changed
+	sources map[sourceKey]*sourceFile
+
+	// editorErr is shown until the next key press when the editor couldn't be opened
+	editorErr error
 }
 
 type cursor struct {
@@ -72,16 +111,25 @@ func NewSiftModel(opts SiftOptions) *siftModel {
 		mode = viewModeInline
 	}
 
+	help := helpview.New()
+	help.ShortSeparator = glyphs.HelpSeparator
+	help.Ellipsis = glyphs.Ellipsis
+
 	return &siftModel{
 		opts: opts,
 		testManager: tests.NewTestManager(tests.TestManagerOpts{
 			ParseLogs: opts.PrettifyLogs,
 		}),
 		testState:      make(map[tests.TestReference]*testState),
+		packageDirs:    newPackageDirs(),
+		keySequence:    keys.sequences(),
+		marks:          make(map[string]tests.TestReference),
+		hungTests:      make(map[tests.TestReference]bool),
+		sources:        make(map[sourceKey]*sourceFile),
 		autoToggleMode: false,
-		compileSpinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
+		compileSpinner: spinner.New(spinner.WithSpinner(glyphs.Compiling)),
 		runningSpinner: spinner.New(spinner.WithSpinner(CenterDotPulse)),
-		help:           helpview.New(),
+		help:           help,
 		cursor: &cursor{
 			test: 0,
 			log:  0,
@@ -98,10 +146,14 @@ func normalizeSearchQuery(query string) string {
 }
 
 func (m *siftModel) isTestVisible(test *tests.TestNode) bool {
+	if m.zoom != nil && !m.zoom.Contains(test.Ref) {
+		return false
+	}
+
 	searchQuery := m.searchInput.Value()
 	if searchQuery != "" {
 		normalizedQuery := normalizeSearchQuery(searchQuery)
-		if !fuzzy.MatchFold(normalizedQuery, test.Ref.Test) {
+		if !fuzzy.MatchFold(normalizedQuery, test.Ref.Test) && !matchesAttr(normalizedQuery, test.Attrs) {
 			return false
 		}
 	}
@@ -109,6 +161,18 @@ func (m *siftModel) isTestVisible(test *tests.TestNode) bool {
 	return true
 }
 
+// matchesAttr checks if the search query matches any of the test attributes in
+// the form key=value
+func matchesAttr(normalizedQuery string, attrs []tests.TestAttr) bool {
+	for _, attr := range attrs {
+		if fuzzy.MatchFold(normalizedQuery, normalizeSearchQuery(attr.Key+"="+attr.Value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // isTestVisible checks if a test passes the current search filter
 func (m *siftModel) isTestVisibleByIndex(testIndex int) bool {
 	test := m.testManager.GetTest(testIndex)
@@ -207,19 +271,45 @@ func (m *siftModel) NextTest() {
 	}
 }
 
+// diagnosticIndex finds the next log of a package which failed to build that
+// is a diagnostic, stepping from the log in the direction of step
+func (m *siftModel) diagnosticIndex(test *tests.TestNode, from, step int) (int, bool) {
+	if test == nil || !test.Status.BuildFailure() {
+		return 0, false
+	}
+
+	logs := m.getLogs(test.Ref)
+	for i := from; i >= 0 && i < len(logs); i += step {
+		if _, ok := tests.LogDiagnostic(logs[i]); ok {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
 func (m *siftModel) PrevFailingTest() {
+	// step through the diagnostics of a package which failed to build first
+	if logIdx, ok := m.diagnosticIndex(m.testManager.GetTest(m.cursor.test), m.cursor.log-1, -1); ok {
+		m.cursor.log = logIdx
+		return
+	}
+
 	if m.cursor.test <= 0 {
 		return
 	}
 
+	subtests := m.testManager.GetSubtestCounts()
+
 	// Find the previous visible failing test
 	for i := m.cursor.test - 1; i >= 0; i-- {
 		if !m.isTestVisibleByIndex(i) {
 			continue
 		}
 
+		// land on the subtests which caused the failure, not every ancestor
 		test := m.testManager.GetTest(i)
-		if test != nil && test.Status != "fail" {
+		if test != nil && !m.testManager.FailedItself(test, subtests[test.Ref]) {
 			continue
 		}
 
@@ -233,23 +323,36 @@ func (m *siftModel) PrevFailingTest() {
 
 		m.cursor.test = i
 		m.cursor.log = 0
+
+		// land on the last diagnostic when moving backwards
+		if logIdx, ok := m.diagnosticIndex(test, len(m.getLogs(test.Ref))-1, -1); ok {
+			m.cursor.log = logIdx
+		}
 		return
 	}
 }
 
 func (m *siftModel) NextFailingTest() {
+	if logIdx, ok := m.diagnosticIndex(m.testManager.GetTest(m.cursor.test), m.cursor.log+1, 1); ok {
+		m.cursor.log = logIdx
+		return
+	}
+
 	if m.cursor.test >= m.testManager.GetTestCount() {
 		return
 	}
 
+	subtests := m.testManager.GetSubtestCounts()
+
 	// Find the next visible failing test
 	for i := m.cursor.test + 1; i < m.testManager.GetTestCount(); i++ {
 		if !m.isTestVisibleByIndex(i) {
 			continue
 		}
 
+		// land on the subtests which caused the failure, not every ancestor
 		test := m.testManager.GetTest(i)
-		if test != nil && test.Status != "fail" {
+		if test != nil && !m.testManager.FailedItself(test, subtests[test.Ref]) {
 			continue
 		}
 
@@ -274,7 +377,7 @@ func (m *siftModel) CursorDown() {
 
 	logCount := 0
 	if state.toggled {
-		logCount = m.testManager.GetLogCount(test.Ref)
+		logCount = len(m.getLogs(test.Ref))
 	}
 
 	// check if there are more logs we can highlight.
@@ -308,6 +411,39 @@ func (m *siftModel) CursorDown() {
 	}
 }
 
+// getLogs returns the logs of a test which should be shown
+func (m *siftModel) getLogs(testRef tests.TestReference) []logparse.LogEntry {
+	logs := groupExampleLogs(testRef, groupDiffLogs(m.testManager.GetLogs(testRef)))
+
+	if !m.failuresOnly {
+		return logs
+	}
+
+	return slices.DeleteFunc(slices.Clone(logs), func(log logparse.LogEntry) bool {
+		return !log.IsError()
+	})
+}
+
+// cursorLog returns the log under the cursor, if the test is expanded
+func (m *siftModel) cursorLog() (*testState, logparse.LogEntry, bool) {
+	test := m.testManager.GetTest(m.cursor.test)
+	if test == nil {
+		return nil, logparse.LogEntry{}, false
+	}
+
+	ts, ok := m.testState[test.Ref]
+	if !ok || !ts.toggled {
+		return nil, logparse.LogEntry{}, false
+	}
+
+	logs := m.getLogs(test.Ref)
+	if m.cursor.log >= len(logs) {
+		return nil, logparse.LogEntry{}, false
+	}
+
+	return ts, logs[m.cursor.log], true
+}
+
 // determine the cursor position with respect to the viewport
 func (m *siftModel) GetCursorPos() int {
 	test := m.testManager.GetTest(m.cursor.test)
@@ -321,6 +457,10 @@ func (m *siftModel) GetCursorPos() int {
 		return -1
 	}
 
+	if ts.toggled && m.cursor.log < len(ts.logPositions) {
+		return ts.logPositions[m.cursor.log]
+	}
+
 	pos := ts.viewportPos + m.cursor.log
 
 	if ts.toggled {
@@ -361,7 +501,7 @@ func (m *siftModel) CursorUp() {
 		test := m.testManager.GetTest(m.cursor.test)
 		if state := m.testState[test.Ref]; state.toggled {
 			// set the log to the last log in previous test
-			logCount := m.testManager.GetLogCount(test.Ref)
+			logCount := len(m.getLogs(test.Ref))
 			m.cursor.log = logCount - 1
 		} else {
 			m.cursor.log = 0
@@ -371,44 +511,23 @@ func (m *siftModel) CursorUp() {
 }
 
 func (m *siftModel) Init() tea.Cmd {
-	// initialise key ring buffer with size 2
-	m.keyBuffer = make([]string, 2)
-	return tea.Batch(m.runningSpinner.Tick, m.compileSpinner.Tick)
-}
-
-func (m *siftModel) LastKeysMatch(binding key.Binding) bool {
-
-	for _, key := range binding.Keys() {
-		n := len(key)
-
-		if n > len(m.keyBuffer) {
-			continue
-		}
-
-		lastNKeys := strings.Join(m.keyBuffer[len(m.keyBuffer)-n:], "")
-
-		if lastNKeys == key {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (m *siftModel) BufferKey(msg tea.KeyMsg) {
-	// shift ring buffer left
-	for i := range len(m.keyBuffer) - 1 {
-		m.keyBuffer[i] = m.keyBuffer[i+1]
-	}
-
-	// add new key to end of buffer
-	m.keyBuffer[len(m.keyBuffer)-1] = msg.String()
+	return tea.Batch(m.runningSpinner.Tick, m.compileSpinner.Tick, hangCheck(), m.loadDurations())
 }
 
 const (
 	scrollBuffer = 5
 )
 
+// scrollToCursor scrolls the viewport when the cursor has jumped to a line
+// within 'scrollBuffer' of the top or bottom, or off screen
+func (m *siftModel) scrollToCursor() {
+	if cursorDelta := m.viewport.YOffset - m.GetCursorPos() + scrollBuffer; cursorDelta > 0 {
+		m.viewport.ScrollUp(cursorDelta)
+	} else if cursorDelta := m.GetCursorPos() - m.viewport.YOffset - m.viewport.Height + scrollBuffer; cursorDelta > 0 {
+		m.viewport.ScrollDown(cursorDelta)
+	}
+}
+
 func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var (
 		cmd  tea.Cmd
@@ -439,12 +558,29 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.Width = msg.Width
 			m.searchInput.Width = msg.Width
 		}
+	case editorFinishedMsg:
+		m.editorErr = msg.err
+
+		// the files may have been edited, so the previews are loaded again
+		m.sources = make(map[sourceKey]*sourceFile)
+		cmds = append(cmds, m.loadPreview())
+	case durationsLoadedMsg:
+		m.durations = tests.Durations(msg)
+	case sourceLoadedMsg:
+		// a file which failed to load is tried again the next time it's previewed
+		if msg.source.err != nil {
+			delete(m.sources, msg.key)
+		} else {
+			m.sources[msg.key] = &msg.source
+		}
+	case hangCheckMsg:
+		cmds = append(cmds, m.checkHungTests(time.Time(msg)), hangCheck())
 	case tea.KeyMsg:
 		if m.mode == viewModeInline {
 			return m, nil
 		}
 
-		m.BufferKey(msg)
+		m.editorErr = nil
 
 		if m.searchInput.Focused() {
 			switch {
@@ -471,14 +607,34 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		if key.Matches(msg, keys.Search) {
+		command, ok := m.keySequence.Feed(msg.String())
+		if !ok {
+			return m, nil
+		}
+
+		if command.Matches(keys.Search) {
 			m.searchInput.Focus()
 			m.searchInput.SetValue("")
 			return m, textinput.Blink
 		}
 
+		if command.Matches(keys.NextTab, keys.PrevTab) {
+			m.SwitchTab(command.Matches(keys.NextTab))
+			return m, nil
+		}
+
+		if command.Matches(keys.ShowSkipped) {
+			m.ToggleSkippedTab()
+			return m, nil
+		}
+
+		if m.tab != tabTests {
+			cmds = append(cmds, m.updateTab(command))
+			break
+		}
+
 		switch {
-		case m.LastKeysMatch(keys.ToggleTestsRecursively):
+		case command.Matches(keys.ToggleTestsRecursively):
 			// toggle recursively
 			parentTest := m.testManager.GetTest(m.cursor.test)
 
@@ -496,26 +652,30 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor.log = 0
 			}
 
-		case m.LastKeysMatch(keys.ExpandAllTests):
+		case command.Matches(keys.ExpandAllTests):
 			// expand all
 			for _, test := range m.testManager.GetTests {
 				m.testState[test.Ref].toggled = true
 			}
-		case m.LastKeysMatch(keys.CollapseAllTests):
+		case command.Matches(keys.CollapseAllTests):
 			// collapse all
 			for _, test := range m.testManager.GetTests {
 				m.testState[test.Ref].toggled = false
 			}
 			m.cursor.log = 0
-		case m.LastKeysMatch(keys.ToggleTest):
+		case command.Matches(keys.ToggleTest):
 			// toggle over cursor
 			test := m.testManager.GetTest(m.cursor.test)
 			m.testState[test.Ref].toggled = !m.testState[test.Ref].toggled
-		case m.LastKeysMatch(keys.ExpandTest):
+		case command.Matches(keys.ExpandTest):
 			// expand over cursor
 			test := m.testManager.GetTest(m.cursor.test)
 			m.testState[test.Ref].toggled = true
-		case m.LastKeysMatch(keys.CollapseTest):
+		case command.Matches(keys.ExpandLevel):
+			m.ToggleLevel(true)
+		case command.Matches(keys.CollapseLevel):
+			m.ToggleLevel(false)
+		case command.Matches(keys.CollapseTest):
 			// collapse over cursor
 			test := m.testManager.GetTest(m.cursor.test)
 			m.testState[test.Ref].toggled = false
@@ -524,7 +684,7 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch {
-		case key.Matches(msg, keys.ChangeMode):
+		case command.Matches(keys.ChangeMode):
 			m.autoToggleMode = !m.autoToggleMode
 
 			if m.autoToggleMode {
@@ -536,32 +696,44 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-		case key.Matches(msg, keys.PrevTest):
-			m.PrevTest()
+		case command.Matches(keys.FailuresOnly):
+			m.failuresOnly = !m.failuresOnly
+			m.cursor.log = 0
+
+		case command.Matches(keys.PrevTest):
+			for range command.Count() {
+				m.PrevTest()
+			}
 
 			// scroll up if selected line is within 'scrollBuffer' of the top
 			cursorDelta := m.viewport.YOffset - m.GetCursorPos() + scrollBuffer
 			if cursorDelta > 0 {
 				m.viewport.ScrollUp(cursorDelta)
 			}
-		case key.Matches(msg, keys.NextTest):
-			m.NextTest()
+		case command.Matches(keys.NextTest):
+			for range command.Count() {
+				m.NextTest()
+			}
 
 			// scroll down if selected line is within 'scrollBuffer' of the bottom
 			cursorDelta := m.GetCursorPos() - m.viewport.YOffset - m.viewport.Height + scrollBuffer
 			if cursorDelta > 0 {
 				m.viewport.ScrollDown(cursorDelta)
 			}
-		case key.Matches(msg, keys.PrevFailingTest):
-			m.PrevFailingTest()
+		case command.Matches(keys.PrevFailingTest):
+			for range command.Count() {
+				m.PrevFailingTest()
+			}
 
 			// scroll up if selected line is within 'scrollBuffer' of the top
 			cursorDelta := m.viewport.YOffset - m.GetCursorPos() + scrollBuffer
 			if cursorDelta > 0 {
 				m.viewport.ScrollUp(cursorDelta)
 			}
-		case key.Matches(msg, keys.NextFailingTest):
-			m.NextFailingTest()
+		case command.Matches(keys.NextFailingTest):
+			for range command.Count() {
+				m.NextFailingTest()
+			}
 
 			// scroll down if selected line is within 'scrollBuffer' of the bottom
 			cursorDelta := m.GetCursorPos() - m.viewport.YOffset - m.viewport.Height + scrollBuffer
@@ -569,39 +741,112 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewport.ScrollDown(cursorDelta)
 			}
 
-		case key.Matches(msg, keys.Help):
-			m.help.ShowAll = !m.help.ShowAll
-		case key.Matches(msg, keys.Quit):
-			if m.mode == viewModeAlternate {
-				m.mode = viewModeInline
-				return m, tea.ExitAltScreen
+		case command.Matches(keys.ParentTest, keys.PrevSibling):
+			for range command.Count() {
+				if command.Matches(keys.ParentTest) {
+					m.ParentTest()
+				} else {
+					m.PrevSibling()
+				}
 			}
-			if !m.endTime.IsZero() {
-				return m, tea.Quit
+
+			// scroll up if selected line is within 'scrollBuffer' of the top
+			cursorDelta := m.viewport.YOffset - m.GetCursorPos() + scrollBuffer
+			if cursorDelta > 0 {
+				m.viewport.ScrollUp(cursorDelta)
+			}
+		case command.Matches(keys.FirstChild, keys.NextSibling):
+			for range command.Count() {
+				if command.Matches(keys.FirstChild) {
+					m.FirstChild()
+				} else {
+					m.NextSibling()
+				}
 			}
-		case key.Matches(msg, keys.ClearSearch):
-			// Clear search filter when esc is pressed and not in search mode
+
+			// scroll down if selected line is within 'scrollBuffer' of the bottom
+			cursorDelta := m.GetCursorPos() - m.viewport.YOffset - m.viewport.Height + scrollBuffer
+			if cursorDelta > 0 {
+				m.viewport.ScrollDown(cursorDelta)
+			}
+		case command.Matches(keys.Zoom):
+			m.ToggleZoom()
+
+		case command.Matches(keys.GotoTop, keys.GotoBottom):
+			m.GotoTest(command.count, command.Matches(keys.GotoBottom))
+			m.scrollToCursor()
+		case command.Matches(keys.ScreenTop, keys.ScreenMiddle, keys.ScreenBottom):
+			switch {
+			case command.Matches(keys.ScreenTop):
+				m.GotoScreenLine(0)
+			case command.Matches(keys.ScreenMiddle):
+				m.GotoScreenLine(m.viewport.Height / 2)
+			default:
+				m.GotoScreenLine(m.viewport.Height - 1)
+			}
+		case command.Matches(keys.SetMark):
+			if test := m.testManager.GetTest(m.cursor.test); test != nil {
+				m.marks[command.register] = test.Ref
+			}
+		case command.Matches(keys.JumpToMark):
+			m.JumpToMark(command.register)
+			m.scrollToCursor()
+
+		case command.Matches(keys.FoldDiff):
+			if ts, log, ok := m.cursorLog(); ok {
+				if ts.foldedDiffs == nil {
+					ts.foldedDiffs = make(map[int]bool)
+				}
+				ts.foldedDiffs[log.Index] = !ts.foldedDiffs[log.Index]
+			}
+		case command.Matches(keys.OpenEditor):
+			test := m.testManager.GetTest(m.cursor.test)
+			if _, log, ok := m.cursorLog(); ok {
+				if file, line, ok := logLocation(log); ok {
+					cmds = append(cmds, m.openEditor(test.Ref.Package, file, line))
+				}
+			}
+		case command.Matches(keys.CopyLog):
+			if _, log, ok := m.cursorLog(); ok {
+				cmds = append(cmds, copyToClipboard(strings.Join(log.Lines(), "\n")))
+			}
+
+		case command.Matches(keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+		case command.Matches(keys.Quit):
+			if cmd := m.quit(); cmd != nil {
+				return m, cmd
+			}
+		case command.Matches(keys.ClearSearch):
+			// Clear search filter when esc is pressed and not in search mode,
+			// then the zoom once there is no search
 			if m.searchInput.Value() != "" {
 				m.searchInput.SetValue("")
 				m.ensureCursorVisible()
+			} else if m.zoom != nil {
+				m.ClearZoom()
+			}
+		case command.Matches(keys.Up):
+			for range command.Count() {
+				m.CursorUp()
 			}
-		case key.Matches(msg, keys.Up):
-			m.CursorUp()
 
 			// scroll up if selected line is within 'scrollBuffer' of the top
 			cursorDelta := m.viewport.YOffset - m.GetCursorPos() + scrollBuffer
 			if cursorDelta > 0 {
 				m.viewport.ScrollUp(cursorDelta)
 			}
-		case key.Matches(msg, keys.Down):
-			m.CursorDown()
+		case command.Matches(keys.Down):
+			for range command.Count() {
+				m.CursorDown()
+			}
 
 			// scroll down if selected line is within 'scrollBuffer' of the bottom
 			cursorDelta := m.GetCursorPos() - m.viewport.YOffset - m.viewport.Height + scrollBuffer
 			if cursorDelta > 0 {
 				m.viewport.ScrollDown(cursorDelta)
 			}
-		case key.Matches(msg, keys.ToggleTestAlt):
+		case command.Matches(keys.ToggleTestAlt):
 			test := m.testManager.GetTest(m.cursor.test)
 
 			if test != nil {
@@ -614,6 +859,8 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		}
+
+		cmds = append(cmds, m.loadPreview())
 	}
 
 	if m.mode == viewModeAlternate {
@@ -630,6 +877,18 @@ func (m *siftModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// quit leaves the alternate screen, then exits once the tests have finished
+func (m *siftModel) quit() tea.Cmd {
+	if m.mode == viewModeAlternate {
+		m.mode = viewModeInline
+		return tea.ExitAltScreen
+	}
+	if !m.endTime.IsZero() {
+		return tea.Quit
+	}
+	return nil
+}
+
 func (m *siftModel) View() string {
 	if m.mode == viewModeInline {
 		return m.inlineView()
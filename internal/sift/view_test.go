@@ -1,12 +1,24 @@
 package sift
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timtatt/sift/internal/history"
 	"github.com/timtatt/sift/internal/tests"
+	"github.com/timtatt/sift/pkg/benchdiff"
+	"github.com/timtatt/sift/pkg/logparse"
+	"github.com/timtatt/sift/pkg/outputparse"
 )
 
 func TestGetIndentLevel(t *testing.T) {
@@ -153,192 +165,6 @@ func TestGetIndentWithLines(t *testing.T) {
 	}
 }
 
-func TestLastKeysMatch(t *testing.T) {
-	tests := []struct {
-		name      string
-		keyBuffer []string
-		binding   key.Binding
-		want      bool
-	}{
-		{
-			name:      "match two-char sequence zA",
-			keyBuffer: []string{"z", "A"},
-			binding: key.NewBinding(
-				key.WithKeys("zA"),
-			),
-			want: true,
-		},
-		{
-			name:      "match two-char sequence zR",
-			keyBuffer: []string{"z", "R"},
-			binding: key.NewBinding(
-				key.WithKeys("zR"),
-			),
-			want: true,
-		},
-		{
-			name:      "no match wrong sequence",
-			keyBuffer: []string{"z", "X"},
-			binding: key.NewBinding(
-				key.WithKeys("zA"),
-			),
-			want: false,
-		},
-		{
-			name:      "match with history before",
-			keyBuffer: []string{"k", "j", "z", "a"},
-			binding: key.NewBinding(
-				key.WithKeys("za"),
-			),
-			want: true,
-		},
-		{
-			name:      "match single char at end",
-			keyBuffer: []string{"z", "a"},
-			binding: key.NewBinding(
-				key.WithKeys("a"),
-			),
-			want: true,
-		},
-		{
-			name:      "no match buffer too short",
-			keyBuffer: []string{"z"},
-			binding: key.NewBinding(
-				key.WithKeys("zA"),
-			),
-			want: false,
-		},
-		{
-			name:      "match multiple possible keys",
-			keyBuffer: []string{"z", "a"},
-			binding: key.NewBinding(
-				key.WithKeys("za", "enter", " "),
-			),
-			want: true,
-		},
-		{
-			name:      "empty buffer no match",
-			keyBuffer: []string{"", ""},
-			binding: key.NewBinding(
-				key.WithKeys("zA"),
-			),
-			want: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			m := &siftModel{
-				keyBuffer: tt.keyBuffer,
-			}
-			got := m.LastKeysMatch(tt.binding)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
-func TestBufferKey(t *testing.T) {
-	tests := []struct {
-		name           string
-		initialBuffer  []string
-		keyToAdd       string
-		expectedBuffer []string
-	}{
-		{
-			name:           "add to empty buffer",
-			initialBuffer:  []string{"", ""},
-			keyToAdd:       "a",
-			expectedBuffer: []string{"", "a"},
-		},
-		{
-			name:           "add to partially filled buffer",
-			initialBuffer:  []string{"", "z"},
-			keyToAdd:       "a",
-			expectedBuffer: []string{"z", "a"},
-		},
-		{
-			name:           "add to full buffer",
-			initialBuffer:  []string{"z", "a"},
-			keyToAdd:       "b",
-			expectedBuffer: []string{"a", "b"},
-		},
-		{
-			name:           "add multiple times",
-			initialBuffer:  []string{"", ""},
-			keyToAdd:       "x",
-			expectedBuffer: []string{"", "x"},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			m := &siftModel{
-				keyBuffer: make([]string, len(tt.initialBuffer)),
-			}
-			copy(m.keyBuffer, tt.initialBuffer)
-
-			msg := tea.KeyMsg{
-				Type:  tea.KeyRunes,
-				Runes: []rune(tt.keyToAdd),
-			}
-
-			m.BufferKey(msg)
-
-			assert.Equal(t, len(tt.expectedBuffer), len(m.keyBuffer))
-
-			for i := range m.keyBuffer {
-				assert.Equal(t, tt.expectedBuffer[i], m.keyBuffer[i])
-			}
-		})
-	}
-}
-
-func TestBufferKeySequence(t *testing.T) {
-	m := &siftModel{
-		keyBuffer: make([]string, 2),
-	}
-
-	keys := []struct {
-		key      string
-		expected []string
-	}{
-		{"z", []string{"", "z"}},
-		{"A", []string{"z", "A"}},
-		{"k", []string{"A", "k"}},
-	}
-
-	for _, k := range keys {
-		msg := tea.KeyMsg{
-			Type:  tea.KeyRunes,
-			Runes: []rune(k.key),
-		}
-		m.BufferKey(msg)
-
-		for i := range m.keyBuffer {
-			assert.Equal(t, k.expected[i], m.keyBuffer[i])
-		}
-	}
-}
-
-func TestBufferKeyAndLastKeysMatchIntegration(t *testing.T) {
-	m := &siftModel{
-		keyBuffer: make([]string, 2),
-	}
-
-	binding := key.NewBinding(key.WithKeys("zA"))
-
-	assert.False(t, m.LastKeysMatch(binding), "Should not match with empty buffer")
-
-	m.BufferKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
-	assert.False(t, m.LastKeysMatch(binding), "Should not match with only 'z' in buffer")
-
-	m.BufferKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
-	assert.True(t, m.LastKeysMatch(binding), "Should match with 'zA' in buffer")
-
-	m.BufferKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
-	assert.False(t, m.LastKeysMatch(binding), "Should not match after buffer shifted")
-}
-
 func TestNextTest(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -976,3 +802,1178 @@ func TestIsTestVisible_SpaceHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() {
+		setTheme(themes["default"])
+	})
+
+	for name := range themes {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, applyTheme(name))
+		})
+	}
+
+	t.Run("unknown theme", func(t *testing.T) {
+		assert.Error(t, applyTheme("does-not-exist"))
+	})
+}
+
+func TestRegisterThemes(t *testing.T) {
+	t.Cleanup(func() {
+		delete(themes, "mine")
+		delete(themes, "mine-too")
+		delete(themes, "other")
+		setTheme(themes["default"])
+	})
+
+	var configs map[string]ThemeConfig
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"mine": {
+			"base": "high-contrast",
+			"green": "#00AF00",
+			"red": {"light": "#AF0000", "dark": "#FF0000"}
+		},
+		"mine-too": {"pulse": ["1", "2"]}
+	}`), &configs))
+
+	require.NoError(t, RegisterThemes(configs))
+
+	mine := themes["mine"]
+	assert.Equal(t, lipgloss.AdaptiveColor{Light: "#00AF00", Dark: "#00AF00"}, mine.Green)
+	assert.Equal(t, lipgloss.AdaptiveColor{Light: "#AF0000", Dark: "#FF0000"}, mine.Red)
+	assert.Equal(t, themes["high-contrast"].Blue, mine.Blue)
+
+	mineToo := themes["mine-too"]
+	assert.Equal(t, themes["default"].Green, mineToo.Green)
+	assert.Equal(t, []lipgloss.TerminalColor{
+		lipgloss.AdaptiveColor{Light: "1", Dark: "1"},
+		lipgloss.AdaptiveColor{Light: "2", Dark: "2"},
+	}, mineToo.Pulse)
+
+	assert.NoError(t, applyTheme("mine"))
+
+	t.Run("unknown base theme", func(t *testing.T) {
+		assert.Error(t, RegisterThemes(map[string]ThemeConfig{
+			"bad": {Base: "does-not-exist"},
+		}))
+		assert.Error(t, RegisterThemes(map[string]ThemeConfig{
+			"bad":   {Base: "other"},
+			"other": {},
+		}))
+	})
+}
+
+func TestUseASCII(t *testing.T) {
+	t.Cleanup(func() {
+		glyphs = unicodeGlyphs
+		keys.Up.SetHelp("↑/k", "move up")
+		keys.Down.SetHelp("↓/j", "move down")
+		setTheme(themes["default"])
+	})
+
+	useASCII()
+
+	indent := getIndentWithBars(2)
+	assert.NotContains(t, indent, "│")
+	assert.Contains(t, indent, "|")
+
+	for _, frame := range CenterDotPulse.Frames {
+		assert.NotContains(t, frame, "∙")
+	}
+}
+
+func TestTimelineCells(t *testing.T) {
+	start := time.Date(2025, 10, 5, 9, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+
+	tests := []struct {
+		name  string
+		spans []tests.TestSpan
+		want  []timelineCell
+	}{
+		{
+			name:  "no spans",
+			spans: nil,
+			want:  []timelineCell{cellEmpty, cellEmpty, cellEmpty, cellEmpty, cellEmpty},
+		},
+		{
+			name:  "single span",
+			spans: []tests.TestSpan{{Start: at(2), End: at(4)}},
+			want:  []timelineCell{cellEmpty, cellEmpty, cellRunning, cellRunning, cellRunning},
+		},
+		{
+			name: "paused for parallel",
+			spans: []tests.TestSpan{
+				{Start: at(0), End: at(0)},
+				{Start: at(3), End: at(4)},
+			},
+			want: []timelineCell{cellRunning, cellPaused, cellPaused, cellRunning, cellRunning},
+		},
+		{
+			name:  "still running",
+			spans: []tests.TestSpan{{Start: at(1)}},
+			want:  []timelineCell{cellEmpty, cellRunning, cellRunning, cellRunning, cellEmpty},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timelineCells(tt.spans, start, 5*time.Second, 5, at(3))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsTestVisible_Attrs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		searchQuery string
+		want        bool
+	}{
+		{
+			name:        "match attribute value",
+			searchQuery: "payments",
+			want:        true,
+		},
+		{
+			name:        "match attribute key and value",
+			searchQuery: "owner=payments",
+			want:        true,
+		},
+		{
+			name:        "match test name",
+			searchQuery: "TestOwned",
+			want:        true,
+		},
+		{
+			name:        "no match",
+			searchQuery: "owner=search",
+			want:        false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewSiftModel(SiftOptions{})
+
+			m.testManager.AddTestOutput(tests.TestOutputLine{
+				Action:  "run",
+				Package: "test/package",
+				Test:    "TestOwned",
+			})
+			m.testManager.AddTestOutput(tests.TestOutputLine{
+				Action:  "attr",
+				Package: "test/package",
+				Test:    "TestOwned",
+				Key:     "owner",
+				Value:   "payments",
+			})
+
+			m.searchInput.SetValue(tt.searchQuery)
+
+			assert.Equal(t, tt.want, m.isTestVisibleByIndex(0))
+		})
+	}
+}
+
+func TestGetLogs_FailuresOnly(t *testing.T) {
+	m := NewSiftModel(SiftOptions{})
+	testRef := tests.TestReference{Package: "test/package", Test: "TestFailing"}
+
+	for _, line := range []tests.TestOutputLine{
+		{Action: "run", Package: testRef.Package, Test: testRef.Test},
+		{Action: "output", Package: testRef.Package, Test: testRef.Test, Output: "application log\n"},
+		{Action: "output", Package: testRef.Package, Test: testRef.Test, Output: "foo_test.go:12: expected 1\n", OutputType: "error"},
+	} {
+		m.testManager.AddTestOutput(line)
+	}
+
+	assert.Len(t, m.getLogs(testRef), 2)
+
+	m.failuresOnly = true
+	logs := m.getLogs(testRef)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, "foo_test.go:12: expected 1", logs[0].Message)
+
+	// the stored logs are untouched
+	assert.Equal(t, 2, m.testManager.GetLogCount(testRef))
+}
+
+func TestSortBenchmarks(t *testing.T) {
+	result := func(pkg, name string, nsPerOp float64) tests.BenchmarkResult {
+		return tests.BenchmarkResult{
+			Ref: tests.TestReference{Package: pkg, Test: name},
+			Samples: []outputparse.Benchmark{
+				{Name: name, Metrics: []outputparse.BenchmarkMetric{{Value: nsPerOp, Unit: outputparse.UnitNsPerOp}}},
+			},
+		}
+	}
+
+	names := func(results []tests.BenchmarkResult) []string {
+		var names []string
+		for _, r := range results {
+			names = append(names, r.Ref.Test)
+		}
+		return names
+	}
+
+	results := []tests.BenchmarkResult{
+		result("pkg/b", "BenchmarkSlow", 500),
+		result("pkg/a", "BenchmarkB", 10),
+		result("pkg/a", "BenchmarkA", 20),
+		result("pkg/a", "BenchmarkC", 30),
+	}
+
+	sortBenchmarks(results, benchmarkSortName)
+	assert.Equal(t, []string{"BenchmarkA", "BenchmarkB", "BenchmarkC", "BenchmarkSlow"}, names(results))
+
+	sortBenchmarks(results, benchmarkSortTime)
+	assert.Equal(t, []string{"BenchmarkC", "BenchmarkA", "BenchmarkB", "BenchmarkSlow"}, names(results))
+
+	// benchmarks without the metric are sorted last
+	sortBenchmarks(results, benchmarkSortAllocs)
+	assert.Equal(t, []string{"BenchmarkA", "BenchmarkB", "BenchmarkC", "BenchmarkSlow"}, names(results))
+}
+
+func TestFormatMetric(t *testing.T) {
+	assert.Equal(t, "1235", formatMetric(1234.5678))
+	assert.Equal(t, "56.8", formatMetric(56.78))
+	assert.Equal(t, "2.23", formatMetric(2.227))
+	assert.Equal(t, "8", formatMetric(8))
+	assert.Equal(t, "0", formatMetric(0))
+}
+
+func TestBenchmarksView(t *testing.T) {
+	m := NewSiftModel(SiftOptions{})
+
+	m.testManager.AddTestOutput(tests.TestOutputLine{
+		Action:  "output",
+		Package: "test/package",
+		Test:    "BenchmarkJoin",
+		Output:  "BenchmarkJoin-8   \t    1000\t        60.07 ns/op\t       8 B/op\t       1 allocs/op\t 42.00 widgets/op\n",
+	})
+
+	view := m.benchmarksView()
+	assert.Contains(t, view, "test/package")
+	assert.Contains(t, view, "widgets/op")
+	assert.Contains(t, view, "Join")
+	assert.Contains(t, view, "60.1")
+}
+
+func TestFormatDelta(t *testing.T) {
+	c := benchdiff.Compare("ns/op", []float64{100, 101, 102, 103, 104}, []float64{120, 121, 122, 123, 124})
+	assert.Equal(t, "+19.61% (p=0.008 n=5+5)", formatDelta(c))
+
+	c = benchdiff.Compare("ns/op", []float64{100, 120, 110}, []float64{105, 115, 112})
+	assert.Equal(t, "~ (p=1.000 n=3+3)", formatDelta(c))
+}
+
+func TestBenchDiffOutput(t *testing.T) {
+	diffs := []tests.BenchmarkDiff{
+		{
+			Ref: tests.TestReference{Package: "bench", Test: "BenchmarkJoin"},
+			Metrics: []benchdiff.Comparison{
+				benchdiff.Compare("ns/op", []float64{100, 101, 102, 103, 104}, []float64{120, 121, 122, 123, 124}),
+				benchdiff.Compare("B/op", []float64{8, 8}, []float64{8, 8}),
+			},
+		},
+	}
+
+	assert.Equal(t, `bench
+  Benchmark  Unit   Old  New                    Delta
+  Join       ns/op  102  122  +19.61% (p=0.008 n=5+5)
+             B/op     8    8        ~ (p=1.000 n=2+2)
+
+Benchmarks: 1, 1 regressed, 0 improved
+`, benchDiffText(diffs))
+
+	assert.Equal(t, "#### `bench`\n\n"+
+		"| Benchmark | Unit | Old | New | Delta |\n"+
+		"| --- | --- | ---: | ---: | --- |\n"+
+		"| Join | ns/op | 102 | 122 | **+19.61% (p=0.008 n=5+5)** |\n"+
+		"|  | B/op | 8 | 8 | ~ (p=1.000 n=2+2) |\n"+
+		"\n"+
+		"Benchmarks: 1, 1 regressed, 0 improved\n", benchDiffMarkdown(diffs))
+
+	assert.Equal(t, "Benchmarks: 0, 0 regressed, 0 improved\n", benchDiffText(nil))
+}
+
+func TestFuzzView(t *testing.T) {
+	test := &tests.TestNode{
+		Ref:    tests.TestReference{Package: "fuzz", Test: "FuzzReverse"},
+		Status: tests.StatusRunning,
+	}
+	assert.Empty(t, fuzzView(test, ""))
+
+	test.Fuzz = &tests.FuzzStatus{
+		Progress: outputparse.FuzzProgress{
+			Elapsed:          3 * time.Second,
+			Execs:            1000,
+			ExecsPerSec:      333,
+			TotalInteresting: 2,
+			Phase:            "minimizing 31-byte failing input file",
+		},
+	}
+
+	view := fuzzView(test, "")
+	assert.Contains(t, view, "elapsed: 3s, execs: 1000 (333/sec), new interesting: 0 (total: 2)")
+	assert.Contains(t, view, "minimizing")
+	assert.NotContains(t, view, "reproduce")
+
+	test.Status = tests.StatusFailed
+	test.Fuzz.FailingInput = "testdata/fuzz/FuzzReverse/1de061fa29cfbb3d"
+
+	view = fuzzView(test, "")
+	assert.NotContains(t, view, "minimizing")
+	assert.Contains(t, view, "testdata/fuzz/FuzzReverse/1de061fa29cfbb3d")
+	assert.Contains(t, view, "go test -run=FuzzReverse/1de061fa29cfbb3d fuzz")
+}
+
+func TestIsTestFrame(t *testing.T) {
+	tests := []struct {
+		function string
+		testName string
+		want     bool
+	}{
+		{function: "example.TestFoo", testName: "TestFoo", want: true},
+		{function: "example.TestFoo.func1.2", testName: "TestFoo/bar", want: true},
+		{function: "github.com/x/example.(*MySuite).TestBar", testName: "TestMySuite/TestBar", want: true},
+		{function: "example.TestFooBar", testName: "TestFoo", want: false},
+		{function: "testing.tRunner", testName: "TestFoo", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTestFrame(outputparse.StackFrame{Function: tt.function}, tt.testName))
+		})
+	}
+}
+
+func TestPanicView(t *testing.T) {
+	test := &tests.TestNode{
+		Ref:    tests.TestReference{Package: "example", Test: "TestFoo"},
+		Status: tests.StatusFailed,
+	}
+	assert.Empty(t, panicView(test, "", true))
+
+	test.Panic = &outputparse.Panic{
+		Message: "runtime error: invalid memory address or nil pointer dereference\n[signal SIGSEGV]",
+		Goroutines: []outputparse.Goroutine{
+			{
+				ID:    7,
+				State: "running",
+				Frames: []outputparse.StackFrame{
+					{Function: "example.TestFoo", File: "/src/example/foo_test.go", Line: 12},
+				},
+			},
+		},
+	}
+
+	collapsed := panicView(test, "", false)
+	assert.Contains(t, collapsed, "nil pointer dereference")
+	assert.NotContains(t, collapsed, "SIGSEGV")
+	assert.NotContains(t, collapsed, "goroutine 7")
+
+	expanded := panicView(test, "", true)
+	assert.Contains(t, expanded, "SIGSEGV")
+	assert.Contains(t, expanded, "goroutine 7 [running]")
+	assert.Contains(t, expanded, "example.TestFoo")
+	assert.Contains(t, expanded, "/src/example/foo_test.go:12")
+}
+
+func TestGoroutineGroupLabel(t *testing.T) {
+	assert.Equal(t, "goroutine 7 [running]", goroutineGroupLabel(outputparse.GoroutineGroup{
+		IDs:   []int{7},
+		State: "running",
+	}))
+
+	assert.Equal(t, "3 goroutines [sync.Mutex.Lock]: 10, 11, 12", goroutineGroupLabel(outputparse.GoroutineGroup{
+		IDs:   []int{10, 11, 12},
+		State: "sync.Mutex.Lock",
+	}))
+}
+
+func TestRaceView(t *testing.T) {
+	test := &tests.TestNode{
+		Ref:    tests.TestReference{Package: "example", Test: "TestRace"},
+		Status: tests.StatusFailed,
+		Races: []outputparse.Race{
+			{
+				Accesses: []outputparse.RaceAccess{
+					{
+						Kind:      "Read",
+						Address:   "0x00c0000182b8",
+						Goroutine: "goroutine 9",
+						Frames:    []outputparse.StackFrame{{Function: "example.TestRace.func1", File: "/src/example/race_test.go", Line: 12}},
+					},
+					{
+						Kind:      "Previous write",
+						Address:   "0x00c0000182b8",
+						Goroutine: "goroutine 10",
+						Frames:    []outputparse.StackFrame{{Function: "example.TestRace.func2", File: "/src/example/race_test.go", Line: 13}},
+					},
+				},
+				Goroutines: []outputparse.RaceGoroutine{
+					{ID: 9, State: "running", Frames: []outputparse.StackFrame{{Function: "example.TestRace", File: "/src/example/race_test.go", Line: 11}}},
+				},
+			},
+		},
+	}
+
+	collapsed := raceView(test, "", false)
+	assert.Contains(t, collapsed, "read by goroutine 9 at race_test.go:12, previous write by goroutine 10 at race_test.go:13")
+	assert.NotContains(t, collapsed, "created at")
+
+	expanded := raceView(test, "", true)
+	assert.Contains(t, expanded, "Previous write at 0x00c0000182b8 by goroutine 10")
+	assert.Contains(t, expanded, "goroutine 9 (running) created at")
+	assert.Contains(t, expanded, "/src/example/race_test.go:11")
+}
+
+func TestAssertionView(t *testing.T) {
+	failure := outputparse.AssertionFailure{
+		File:     "/src/example/foo_test.go",
+		Line:     16,
+		Message:  "Not equal:",
+		Expected: "3",
+		Actual:   "4",
+		Diff:     []string{"--- Expected", "+++ Actual", "@@ -1 +1 @@", "-3", "+4"},
+		Messages: "counts should match",
+	}
+
+	view := assertionView(failure, lipgloss.NewStyle())
+	assert.Equal(t, []string{
+		"foo_test.go:16: Not equal:",
+		"  expected: 3",
+		"  actual  : 4",
+		"  --- Expected",
+		"  +++ Actual",
+		"  @@ -1 +1 @@",
+		"  -3",
+		"  +4",
+		"  messages: counts should match",
+	}, strings.Split(view, "\n"))
+}
+
+func TestGetCursorPos_MultilineLogs(t *testing.T) {
+	m := createTestModel(testModelOpts{testCount: 1, logCount: 3})
+
+	testRef := m.testManager.GetTest(0).Ref
+	m.testState[testRef] = &testState{
+		toggled:      true,
+		viewportPos:  2,
+		logPositions: []int{3, 4, 9},
+	}
+
+	m.cursor.log = 2
+	assert.Equal(t, 9, m.GetCursorPos())
+}
+
+func TestGroupDiffLogs(t *testing.T) {
+	logs := []logparse.LogEntry{
+		{Message: "    foo_test.go:10: starting"},
+		{Message: "    foo_test.go:12: mismatch (-want +got):"},
+		{Message: "          []string{"},
+		{Message: "        - \t\"a\","},
+		{Message: "        + \t\"b\","},
+		{Message: "          }"},
+		{Message: "    foo_test.go:14: done"},
+	}
+
+	grouped := groupDiffLogs(logs)
+	require.Len(t, grouped, 3)
+	assert.Equal(t, "    foo_test.go:12: mismatch (-want +got):", grouped[1].Message)
+	assert.Equal(t, []string{"          []string{", "        - \t\"a\",", "        + \t\"b\",", "          }"}, grouped[1].Continuation)
+	assert.Equal(t, "    foo_test.go:14: done", grouped[2].Message)
+
+	// the original logs are left untouched
+	assert.Empty(t, logs[1].Continuation)
+}
+
+func TestDiffView(t *testing.T) {
+	lines := []string{
+		"mismatch (-want +got):",
+		"  []string{",
+		"- \t\"a\",",
+		"+ \t\"b\",",
+		"  }",
+	}
+
+	view, ok := diffView(lines, lipgloss.NewStyle(), false)
+	require.True(t, ok)
+	assert.Equal(t, []string{
+		"mismatch (-want +got):",
+		" │ []string{",
+		"-│     \"a\",",
+		"+│     \"b\",",
+		" │ }",
+	}, strings.Split(view, "\n"))
+
+	folded, ok := diffView(lines, lipgloss.NewStyle(), true)
+	require.True(t, ok)
+	assert.Equal(t, "mismatch (-want +got): [-1 +1, 4 lines folded]", folded)
+
+	_, ok = diffView([]string{"no diff here"}, lipgloss.NewStyle(), false)
+	assert.False(t, ok)
+}
+
+func TestFoldDiff(t *testing.T) {
+	m := NewSiftModel(SiftOptions{})
+	m.Init()
+	ref := tests.TestReference{Package: "test/package", Test: "TestDiffs"}
+
+	m.testManager.AddTestOutput(tests.TestOutputLine{Action: "run", Package: ref.Package, Test: ref.Test})
+	for _, line := range []int{12, 20} {
+		m.testManager.AddTestOutput(tests.TestOutputLine{Action: "output", Package: ref.Package, Test: ref.Test, Output: fmt.Sprintf("    foo_test.go:%d: mismatch (-want +got):\n", line), OutputType: "error"})
+		m.testManager.AddTestOutput(tests.TestOutputLine{Action: "output", Package: ref.Package, Test: ref.Test, Output: "        - a\n", OutputType: "error-continue"})
+		m.testManager.AddTestOutput(tests.TestOutputLine{Action: "output", Package: ref.Package, Test: ref.Test, Output: "        + b\n", OutputType: "error-continue"})
+	}
+
+	m.testState[ref] = &testState{toggled: true}
+	m.cursor.log = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+
+	// only the log under the cursor is folded, even when logs share a time
+	assert.Equal(t, map[int]bool{1: true}, m.testState[ref].foldedDiffs)
+
+	content, _ := m.testView()
+	assert.Contains(t, content, "foo_test.go:12: mismatch (-want +got):\n")
+	assert.Contains(t, content, "foo_test.go:20: mismatch (-want +got): [-1 +1, 2 lines folded]")
+}
+
+func TestLogLocation(t *testing.T) {
+	file, line, ok := logLocation(logparse.LogEntry{Message: "    foo_test.go:42: expected 3"})
+	require.True(t, ok)
+	assert.Equal(t, "foo_test.go", file)
+	assert.Equal(t, 42, line)
+
+	// the error trace of testify is preferred as it's an absolute path
+	file, line, ok = logLocation(logparse.LogEntry{
+		Message: "    foo_test.go:16: ",
+		Continuation: []string{
+			"        \tError Trace:\t/src/example/foo_test.go:16",
+			"        \tError:      \tShould be true",
+		},
+	})
+	require.True(t, ok)
+	assert.Equal(t, "/src/example/foo_test.go", file)
+	assert.Equal(t, 16, line)
+
+	_, _, ok = logLocation(logparse.LogEntry{Message: "application log"})
+	assert.False(t, ok)
+}
+
+func TestPackageDirsResolve(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo_test.go"), nil, 0o644))
+
+	pd := newPackageDirs()
+	pd.dirs["example.com/foo"] = dir
+
+	path, err := pd.resolve("example.com/foo", "foo_test.go")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "foo_test.go"), path)
+
+	path, err = pd.resolve("example.com/foo", "/src/example/foo_test.go")
+	require.NoError(t, err)
+	assert.Equal(t, "/src/example/foo_test.go", path)
+
+	_, err = pd.resolve("example.com/foo", "missing_test.go")
+	assert.Error(t, err)
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nvim -p")
+
+	cmd, err := editorCommand("/src/example/foo_test.go", 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nvim", "-p", "+42", "/src/example/foo_test.go"}, cmd.Args)
+
+	t.Setenv("EDITOR", "")
+	_, err = editorCommand("/src/example/foo_test.go", 42)
+	assert.Error(t, err)
+}
+
+func TestHighlightGo(t *testing.T) {
+	src := "package foo\n\n/* a comment\nacross lines */\nfunc Foo() string {\n\treturn `raw\nstring`\n}\n"
+
+	// styles aren't rendered in tests, so the lines should match the source
+	assert.Equal(t, strings.Split(src, "\n"), highlightGo([]byte(src)))
+}
+
+func TestPreviewView(t *testing.T) {
+	m := createTestModel(testModelOpts{testCount: 1})
+	m.viewport.Width = 80
+
+	test := m.testManager.GetTest(0)
+	m.testManager.AddTestOutput(tests.TestOutputLine{
+		Action:  "output",
+		Package: test.Ref.Package,
+		Test:    test.Ref.Test,
+		Output:  "    foo_test.go:8: expected 3\n",
+	})
+	m.testState[test.Ref].toggled = true
+
+	// nothing is shown while the file is loading
+	assert.NotNil(t, m.loadPreview())
+	assert.Nil(t, m.loadPreview())
+	assert.Empty(t, m.previewView())
+
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	m.sources[sourceKey{pkg: test.Ref.Package, file: "foo_test.go"}] = &sourceFile{
+		path:  "/src/example/foo_test.go",
+		lines: lines,
+	}
+
+	assert.Equal(t, []string{
+		"foo_test.go:8",
+		"   3 │ line 3",
+		"   4 │ line 4",
+		"   5 │ line 5",
+		"   6 │ line 6",
+		"   7 │ line 7",
+		">  8 │ line 8",
+		"   9 │ line 9",
+		"  10 │ line 10",
+		"  11 │ line 11",
+		"  12 │ line 12",
+		"  13 │ line 13",
+	}, strings.Split(m.previewView(), "\n"))
+}
+
+func TestPreviewView_Reload(t *testing.T) {
+	m := createTestModel(testModelOpts{testCount: 1})
+	m.viewport.Width = 80
+
+	test := m.testManager.GetTest(0)
+	m.testManager.AddTestOutput(tests.TestOutputLine{
+		Action:  "output",
+		Package: test.Ref.Package,
+		Test:    test.Ref.Test,
+		Output:  "    foo_test.go:1: expected 3\n",
+	})
+	m.testState[test.Ref].toggled = true
+
+	key := sourceKey{pkg: test.Ref.Package, file: "foo_test.go"}
+
+	// a file which failed to load is loaded again
+	assert.NotNil(t, m.loadPreview())
+	m.Update(sourceLoadedMsg{key: key, source: sourceFile{err: os.ErrNotExist}})
+	assert.NotContains(t, m.sources, key)
+	assert.NotNil(t, m.loadPreview())
+
+	m.Update(sourceLoadedMsg{key: key, source: sourceFile{path: "/src/example/foo_test.go", lines: []string{"before"}}})
+	assert.Contains(t, m.previewView(), "before")
+	assert.Nil(t, m.loadPreview())
+
+	// the file may have been edited while the editor was open
+	m.Update(editorFinishedMsg{})
+	assert.Empty(t, m.previewView())
+	assert.Nil(t, m.loadPreview())
+
+	m.Update(sourceLoadedMsg{key: key, source: sourceFile{path: "/src/example/foo_test.go", lines: []string{"after"}}})
+	assert.Contains(t, m.previewView(), "after")
+}
+
+func TestFailingTestJumps_Diagnostics(t *testing.T) {
+	m := createTestModel(testModelOpts{testStatuses: []string{"pass", "fail"}})
+
+	for _, output := range []string{"# example [example.test]\n", "./foo.go:3:23: undefined: Bar\n", "too many errors\n", "./foo.go:4:2: undefined: Baz\n"} {
+		m.testManager.AddTestOutput(tests.TestOutputLine{Action: "build-output", ImportPath: "example [example.test]", Output: output})
+	}
+	m.testManager.AddTestOutput(tests.TestOutputLine{Action: "build-fail", ImportPath: "example [example.test]"})
+
+	// the package which failed to build is inserted before the tests
+	m.testState[m.testManager.GetTest(0).Ref] = &testState{toggled: true}
+
+	m.NextFailingTest()
+	assert.Equal(t, 0, m.cursor.test)
+	assert.Equal(t, 2, m.cursor.log)
+
+	m.NextFailingTest()
+	assert.Equal(t, 2, m.cursor.test)
+	assert.Equal(t, 0, m.cursor.log)
+
+	m.PrevFailingTest()
+	assert.Equal(t, 0, m.cursor.test)
+	assert.Equal(t, 2, m.cursor.log)
+
+	m.PrevFailingTest()
+	assert.Equal(t, 0, m.cursor.test)
+	assert.Equal(t, 0, m.cursor.log)
+}
+
+func TestDiagnosticView(t *testing.T) {
+	view := diagnosticView(outputparse.Diagnostic{
+		File:    "./foo_test.go",
+		Line:    6,
+		Column:  10,
+		Message: "not enough arguments in call to Foo\nhave ()\nwant (int)",
+	}, lipgloss.NewStyle())
+
+	assert.Equal(t, "./foo_test.go:6:10: not enough arguments in call to Foo\n    have ()\n    want (int)", view)
+}
+
+func TestDiagnosticCount(t *testing.T) {
+	test := &tests.TestNode{
+		Status:      tests.StatusBuildError,
+		Diagnostics: []outputparse.Diagnostic{{File: "foo.go", Line: 1}, {File: "foo.go", Line: 2}},
+	}
+	assert.Equal(t, " 2 errors", diagnosticCount(test))
+
+	test.Status = tests.StatusVetError
+	assert.Equal(t, " 2 issues", diagnosticCount(test))
+
+	test.Diagnostics = test.Diagnostics[:1]
+	assert.Equal(t, " 1 issue", diagnosticCount(test))
+}
+
+func TestGroupExampleLogs(t *testing.T) {
+	logs := []logparse.LogEntry{
+		{Message: "got:"},
+		{Message: "a"},
+		{Message: "b"},
+		{Message: "want:"},
+		{Message: "a"},
+		{Message: "x"},
+	}
+
+	grouped := groupExampleLogs(tests.TestReference{Package: "pkg", Test: "ExampleFoo"}, logs)
+	require.Len(t, grouped, 1)
+	assert.True(t, grouped[0].IsError())
+	assert.Equal(t, []string{"got:", "a", "b", "want:", "a", "x"}, grouped[0].Lines())
+
+	// only the output of examples is grouped
+	assert.Equal(t, logs, groupExampleLogs(tests.TestReference{Package: "pkg", Test: "TestFoo"}, logs))
+}
+
+func TestExampleView(t *testing.T) {
+	failure := outputparse.ExampleFailure{
+		Got:  []string{"a", "b", "c"},
+		Want: []string{"a", "x", "c"},
+	}
+
+	view := exampleView(failure, lipgloss.NewStyle(), false)
+	assert.Equal(t, []string{
+		"output mismatch (-want +got):",
+		" │a",
+		"-│x",
+		"+│b",
+		" │c",
+	}, strings.Split(view, "\n"))
+
+	folded := exampleView(failure, lipgloss.NewStyle(), true)
+	assert.Equal(t, "output mismatch (-want +got): [-1 +1, 4 lines folded]", folded)
+}
+
+func TestExampleView_Unordered(t *testing.T) {
+	failure := outputparse.ExampleFailure{
+		Got:       []string{"2", "1"},
+		Want:      []string{"1", "3"},
+		Unordered: true,
+	}
+
+	view := exampleView(failure, lipgloss.NewStyle(), false)
+	assert.Equal(t, []string{
+		"unordered output mismatch (-want +got):",
+		" │1",
+		"-│3",
+		"+│2",
+	}, strings.Split(view, "\n"))
+}
+
+func TestSkippedView(t *testing.T) {
+	m := NewSiftModel(SiftOptions{})
+	assert.Equal(t, "No tests were skipped", m.skippedView())
+
+	for _, line := range []tests.TestOutputLine{
+		{Action: "run", Package: "test/package", Test: "TestDocker"},
+		{Action: "output", Package: "test/package", Test: "TestDocker", Output: "    foo_test.go:10: needs docker\n"},
+		{Action: "skip", Package: "test/package", Test: "TestDocker"},
+		{Action: "run", Package: "test/package", Test: "TestPass"},
+		{Action: "pass", Package: "test/package", Test: "TestPass"},
+	} {
+		m.testManager.AddTestOutput(line)
+	}
+
+	test := m.testManager.GetTest(0)
+	assert.Equal(t, " last log: needs docker", getSkipReason(test))
+	assert.Empty(t, getSkipReason(m.testManager.GetTest(1)))
+
+	assert.Equal(t, []string{
+		glyphs.Skip + " last log: needs docker (1)",
+		"  test/package TestDocker",
+		"",
+	}, strings.Split(m.skippedView(), "\n"))
+}
+
+func TestFailingTestJumps_Subtests(t *testing.T) {
+	m := NewSiftModel(SiftOptions{})
+
+	for _, line := range []tests.TestOutputLine{
+		{Action: "run", Package: "test/package", Test: "TestA"},
+		{Action: "run", Package: "test/package", Test: "TestA/one"},
+		{Action: "fail", Package: "test/package", Test: "TestA/one"},
+		{Action: "run", Package: "test/package", Test: "TestA/two"},
+		{Action: "pass", Package: "test/package", Test: "TestA/two"},
+		{Action: "fail", Package: "test/package", Test: "TestA"},
+		{Action: "run", Package: "test/package", Test: "TestB"},
+		{Action: "run", Package: "test/package", Test: "TestB/one"},
+		{Action: "fail", Package: "test/package", Test: "TestB/one"},
+		{Action: "fail", Package: "test/package", Test: "TestB"},
+	} {
+		m.testManager.AddTestOutput(line)
+	}
+
+	for _, test := range m.testManager.GetTests {
+		m.testState[test.Ref] = &testState{}
+	}
+
+	// the parents only failed because of their subtests, so they are skipped
+	m.NextFailingTest()
+	assert.Equal(t, "TestA/one", m.testManager.GetTest(m.cursor.test).Ref.Test)
+
+	m.NextFailingTest()
+	assert.Equal(t, "TestB/one", m.testManager.GetTest(m.cursor.test).Ref.Test)
+
+	m.PrevFailingTest()
+	assert.Equal(t, "TestA/one", m.testManager.GetTest(m.cursor.test).Ref.Test)
+
+	assert.Equal(t, " 1 of 2 subtests failed", getSubtestFailures(m.testManager.GetSubtestCounts()[tests.TestReference{Package: "test/package", Test: "TestA"}]))
+}
+
+func createTreeModel() *siftModel {
+	m := NewSiftModel(SiftOptions{})
+
+	for _, name := range []string{"TestA", "TestA/one", "TestA/one/x", "TestA/two", "TestB", "TestB/one"} {
+		m.testManager.AddTestOutput(tests.TestOutputLine{Action: "run", Package: "test/package", Test: name})
+	}
+
+	for _, test := range m.testManager.GetTests {
+		m.testState[test.Ref] = &testState{}
+	}
+
+	return m
+}
+
+func TestTreeNavigation(t *testing.T) {
+	m := createTreeModel()
+
+	cursorTest := func() string {
+		return m.testManager.GetTest(m.cursor.test).Ref.Test
+	}
+
+	m.NextSibling()
+	assert.Equal(t, "TestB", cursorTest())
+
+	m.PrevSibling()
+	assert.Equal(t, "TestA", cursorTest())
+
+	m.FirstChild()
+	assert.Equal(t, "TestA/one", cursorTest())
+
+	// skips over the subtests of TestA/one
+	m.NextSibling()
+	assert.Equal(t, "TestA/two", cursorTest())
+
+	// TestA/two is the last subtest of TestA
+	m.NextSibling()
+	assert.Equal(t, "TestA/two", cursorTest())
+
+	m.FirstChild()
+	assert.Equal(t, "TestA/two", cursorTest())
+
+	m.ParentTest()
+	assert.Equal(t, "TestA", cursorTest())
+
+	m.ParentTest()
+	assert.Equal(t, "TestA", cursorTest())
+}
+
+func TestToggleLevel(t *testing.T) {
+	m := createTreeModel()
+	m.cursor.test = 1 // TestA/one
+
+	m.ToggleLevel(true)
+
+	toggled := make(map[string]bool)
+	for ref, state := range m.testState {
+		toggled[ref.Test] = state.toggled
+	}
+
+	assert.Equal(t, map[string]bool{
+		"TestA":       false,
+		"TestA/one":   true,
+		"TestA/one/x": false,
+		"TestA/two":   true,
+		"TestB":       false,
+		"TestB/one":   false,
+	}, toggled)
+
+	// a sibling which hasn't been rendered yet has no state
+	m.testManager.AddTestOutput(tests.TestOutputLine{Action: "run", Package: "test/package", Test: "TestA/three"})
+	m.ToggleLevel(false)
+
+	assert.False(t, m.testState[tests.TestReference{Package: "test/package", Test: "TestA/three"}].toggled)
+	assert.False(t, m.testState[tests.TestReference{Package: "test/package", Test: "TestA/two"}].toggled)
+}
+
+func TestToggleZoom(t *testing.T) {
+	m := createTreeModel()
+	m.cursor.test = 1 // TestA/one
+
+	m.ToggleZoom()
+	require.NotNil(t, m.zoom)
+
+	var visible []string
+	for _, test := range m.testManager.GetTests {
+		if m.isTestVisible(test) {
+			visible = append(visible, test.Ref.Test)
+		}
+	}
+	assert.Equal(t, []string{"TestA/one", "TestA/one/x"}, visible)
+
+	m.ToggleZoom()
+	assert.Nil(t, m.zoom)
+	assert.True(t, m.isTestVisibleByIndex(0))
+}
+
+func TestKeySequence(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     []string
+		expected []keyCommand
+		pending  string
+	}{
+		{
+			name:     "single key",
+			keys:     []string{"j"},
+			expected: []keyCommand{{keys: "j"}},
+		},
+		{
+			name:     "count",
+			keys:     []string{"1", "0", "j"},
+			expected: []keyCommand{{keys: "j", count: 10}},
+		},
+		{
+			name:     "sequence",
+			keys:     []string{"z", "A"},
+			expected: []keyCommand{{keys: "zA"}},
+		},
+		{
+			name:     "count before sequence",
+			keys:     []string{"3", "g", "g"},
+			expected: []keyCommand{{keys: "gg", count: 3}},
+		},
+		{
+			name:     "register",
+			keys:     []string{"m", "a", "'", "a"},
+			expected: []keyCommand{{keys: "m", register: "a"}, {keys: "'", register: "a"}},
+		},
+		{
+			name:     "invalid register is dropped",
+			keys:     []string{"m", "1", "j"},
+			expected: []keyCommand{{keys: "j"}},
+		},
+		{
+			name:     "invalid sequence is dropped",
+			keys:     []string{"z", "j"},
+			expected: []keyCommand{{keys: "j"}},
+		},
+		{
+			name:     "zero is a key without a count",
+			keys:     []string{"0"},
+			expected: []keyCommand{{keys: "0"}},
+		},
+		{
+			name:    "incomplete",
+			keys:    []string{"5", "z"},
+			pending: "5z",
+		},
+		{
+			name: "esc cancels",
+			keys: []string{"5", "z", "esc"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ks := newKeySequence(
+				[]key.Binding{key.NewBinding(key.WithKeys("zA", "gg"))},
+				[]key.Binding{key.NewBinding(key.WithKeys("m", "'"))},
+			)
+
+			var commands []keyCommand
+			for _, k := range tc.keys {
+				if command, ok := ks.Feed(k); ok {
+					commands = append(commands, command)
+				}
+			}
+
+			assert.Equal(t, tc.expected, commands)
+			assert.Equal(t, tc.pending, ks.String())
+		})
+	}
+}
+
+func pressKeys(m *siftModel, keys ...string) {
+	for _, k := range keys {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+}
+
+func TestKeyCounts(t *testing.T) {
+	m := createTestModel(testModelOpts{testCount: 10})
+
+	pressKeys(m, "3", "j")
+	assert.Equal(t, 3, m.cursor.test)
+
+	pressKeys(m, "2", "k")
+	assert.Equal(t, 1, m.cursor.test)
+
+	pressKeys(m, "G")
+	assert.Equal(t, 9, m.cursor.test)
+
+	pressKeys(m, "g", "g")
+	assert.Equal(t, 0, m.cursor.test)
+
+	pressKeys(m, "5", "G")
+	assert.Equal(t, 4, m.cursor.test)
+}
+
+func TestMarks(t *testing.T) {
+	m := createTestModel(testModelOpts{testCount: 10})
+
+	pressKeys(m, "4", "j", "m", "a", "g", "g")
+	assert.Equal(t, 0, m.cursor.test)
+
+	pressKeys(m, "'", "a")
+	assert.Equal(t, 4, m.cursor.test)
+
+	// unset marks are ignored
+	pressKeys(m, "'", "b")
+	assert.Equal(t, 4, m.cursor.test)
+}
+
+func TestGotoScreenLine(t *testing.T) {
+	m := createTestModel(testModelOpts{testCount: 10})
+	m.viewport.Height = 5
+	m.viewport.SetContent(strings.Repeat("\n", 10))
+	m.viewport.SetYOffset(3)
+
+	m.GotoScreenLine(0)
+	assert.Equal(t, 3, m.cursor.test)
+
+	m.GotoScreenLine(m.viewport.Height / 2)
+	assert.Equal(t, 5, m.cursor.test)
+
+	m.GotoScreenLine(m.viewport.Height - 1)
+	assert.Equal(t, 7, m.cursor.test)
+}
+
+func TestRunningView(t *testing.T) {
+	m := NewSiftModel(SiftOptions{HangThreshold: time.Minute})
+	assert.Empty(t, m.runningView(time.Now()))
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, line := range []tests.TestOutputLine{
+		{Time: start, Action: "run", Package: "test/package", Test: "TestSlow"},
+		{Time: start.Add(50 * time.Second), Action: "run", Package: "test/package", Test: "TestFast"},
+		{Time: start.Add(50 * time.Second), Action: "run", Package: "test/package", Test: "TestFast/sub"},
+	} {
+		m.testManager.AddTestOutput(line)
+	}
+
+	running := m.getStatusIcon(tests.StatusRunning)
+	assert.Equal(t, []string{
+		"Running (2)",
+		running + " test/package TestSlow 1m30s " + glyphs.TimedOut + " possibly hung",
+		running + " test/package TestFast/sub 40s",
+	}, strings.Split(m.runningView(start.Add(90*time.Second)), "\n"))
+}
+
+func TestCheckHungTests(t *testing.T) {
+	m := NewSiftModel(SiftOptions{HangThreshold: time.Minute, Bell: true})
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.testManager.AddTestOutput(tests.TestOutputLine{Time: start, Action: "run", Package: "test/package", Test: "TestSlow"})
+	test := m.testManager.GetTest(0)
+
+	assert.Nil(t, m.checkHungTests(start.Add(30*time.Second)))
+	assert.False(t, m.isHung(test))
+
+	// the bell is only rung the first time the test is flagged
+	assert.NotNil(t, m.checkHungTests(start.Add(time.Minute)))
+	assert.True(t, m.isHung(test))
+	assert.Nil(t, m.checkHungTests(start.Add(2*time.Minute)))
+
+	m.testManager.AddTestOutput(tests.TestOutputLine{Time: start.Add(3 * time.Minute), Action: "pass", Package: "test/package", Test: "TestSlow"})
+	assert.False(t, m.isHung(test))
+}
+
+func TestListPattern(t *testing.T) {
+	assert.Equal(t, ".", listPattern([]string{"./..."}))
+	assert.Equal(t, "TestA", listPattern([]string{"./...", "-run", "TestA"}))
+	assert.Equal(t, "TestA", listPattern([]string{"-run=TestA/one", "./..."}))
+	assert.Equal(t, "TestA", listPattern([]string{"--test.run=TestA"}))
+	assert.Equal(t, ".", listPattern([]string{"./...", "-run"}))
+}
+
+func TestParseTestList(t *testing.T) {
+	output := `{"Action":"start","Package":"test/package"}
+{"Action":"output","Package":"test/package","Output":"TestA\n"}
+{"Action":"output","Package":"test/package","Output":"BenchmarkA\n"}
+{"Action":"output","Package":"test/package","Output":"ExampleA\n"}
+{"Action":"output","Package":"test/package","Output":"ok  \ttest/package\t0.002s\n"}
+{"Action":"pass","Package":"test/package"}
+{"Action":"output","Package":"test/other","Output":"FuzzA\n"}`
+
+	assert.Equal(t, map[string][]string{
+		"test/package": {"TestA", "ExampleA"},
+		"test/other":   {"FuzzA"},
+	}, parseTestList(strings.NewReader(output)))
+}
+
+func TestProgressView(t *testing.T) {
+	m := NewSiftModel(SiftOptions{})
+	assert.Empty(t, m.progressView(time.Now()))
+
+	m.testManager.QueueTests("test/package", []string{"TestA", "TestB"})
+	m.testManager.AddTestOutput(tests.TestOutputLine{Action: "run", Package: "test/package", Test: "TestA"})
+	m.testManager.AddTestOutput(tests.TestOutputLine{Action: "pass", Package: "test/package", Test: "TestA"})
+
+	bar := strings.Repeat(glyphs.ProgressDone, 15) + strings.Repeat(glyphs.ProgressTodo, 15)
+	assert.Equal(t, bar+"  50% 1/2 tests", m.progressView(time.Now()))
+
+	m.durations = tests.Durations{"test/package": {"TestA": time.Second, "TestB": 3 * time.Second}}
+	m.startTime = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, bar+"  50% 1/2 tests ETA 6s", m.progressView(m.startTime.Add(2*time.Second)))
+}
+
+func TestLoadDurations(t *testing.T) {
+	m := NewSiftModel(SiftOptions{})
+	assert.Nil(t, m.loadDurations())
+
+	m.history = history.NewStore(t.TempDir(), "/src/project", 0)
+	require.NoError(t, m.history.Save(history.Run{
+		StartTime: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Tests: []history.TestResult{
+			{Package: "test/package", Test: "TestA", Status: tests.StatusPassed.String(), Elapsed: time.Second},
+		},
+	}))
+
+	cmd := m.loadDurations()
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+
+	duration, ok := m.durations.Get(tests.TestReference{Package: "test/package", Test: "TestA"})
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, duration)
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/timtatt/sift/pkg/benchdiff"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// BenchmarkDiff compares a benchmark which ran in two separate runs
+type BenchmarkDiff struct {
+	Ref TestReference
+
+	// Metrics has a comparison for each unit reported in both runs
+	Metrics []benchdiff.Comparison
+}
+
+type BenchmarkResult struct {
+	Ref TestReference
+
+	// Samples has one result per run of the benchmark when using -count
+	Samples []outputparse.Benchmark
+}
+
+func isBenchmark(testName string) bool {
+	return strings.HasPrefix(testName, "Benchmark")
+}
+
+// Mean averages the metric with the given unit across all samples
+func (br BenchmarkResult) Mean(unit string) (float64, bool) {
+	values := br.Values(unit)
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+
+	return total / float64(len(values)), true
+}
+
+// Values collects the metric with the given unit from each sample
+func (br BenchmarkResult) Values(unit string) []float64 {
+	var values []float64
+	for _, sample := range br.Samples {
+		if v, ok := sample.Metric(unit); ok {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// Units lists every unit reported by the benchmark, in the order they were reported
+func (br BenchmarkResult) Units() []string {
+	var units []string
+	for _, sample := range br.Samples {
+		for _, metric := range sample.Metrics {
+			if !slices.Contains(units, metric.Unit) {
+				units = append(units, metric.Unit)
+			}
+		}
+	}
+
+	return units
+}
+
+// finishBenchmark marks the benchmark as passed once its result is written
+func (tm *TestManager) finishBenchmark(testRef TestReference, t time.Time) {
+	tm.testLock.Lock()
+	defer tm.testLock.Unlock()
+
+	if test := tm.findTest(testRef); test != nil && isBenchmark(testRef.Test) {
+		tm.passBenchmark(test, t)
+	}
+}
+
+func (tm *TestManager) addBenchmark(pkg string, benchmark outputparse.Benchmark) {
+	tm.benchmarkLock.Lock()
+	defer tm.benchmarkLock.Unlock()
+
+	ref := TestReference{
+		Package: pkg,
+		Test:    benchmark.Name,
+	}
+
+	tm.benchmarks[ref] = append(tm.benchmarks[ref], benchmark)
+}
+
+// GetBenchmarks returns the results of all benchmarks sorted by package and name
+func (tm *TestManager) GetBenchmarks() []BenchmarkResult {
+	tm.benchmarkLock.RLock()
+	defer tm.benchmarkLock.RUnlock()
+
+	results := make([]BenchmarkResult, 0, len(tm.benchmarks))
+	for ref, samples := range tm.benchmarks {
+		results = append(results, BenchmarkResult{
+			Ref:     ref,
+			Samples: slices.Clone(samples),
+		})
+	}
+
+	slices.SortFunc(results, func(a, b BenchmarkResult) int {
+		if c := cmp.Compare(a.Ref.Package, b.Ref.Package); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Ref.Test, b.Ref.Test)
+	})
+
+	return results
+}
+
+// DiffBenchmarks compares the benchmarks which are in both the old and new runs
+func DiffBenchmarks(old, new []BenchmarkResult) []BenchmarkDiff {
+	oldResults := make(map[TestReference]BenchmarkResult, len(old))
+	for _, result := range old {
+		oldResults[result.Ref] = result
+	}
+
+	var diffs []BenchmarkDiff
+	for _, newResult := range new {
+		oldResult, ok := oldResults[newResult.Ref]
+		if !ok {
+			continue
+		}
+
+		diff := BenchmarkDiff{Ref: newResult.Ref}
+		for _, unit := range newResult.Units() {
+			oldValues := oldResult.Values(unit)
+			if len(oldValues) == 0 {
+				continue
+			}
+
+			diff.Metrics = append(diff.Metrics, benchdiff.Compare(unit, oldValues, newResult.Values(unit)))
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	slices.SortFunc(diffs, func(a, b BenchmarkDiff) int {
+		if c := cmp.Compare(a.Ref.Package, b.Ref.Package); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Ref.Test, b.Ref.Test)
+	})
+
+	return diffs
+}
@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+const benchmarkOutput = `{"Action":"start","Package":"bench"}
+{"Action":"output","Package":"bench","Output":"goos: linux\n"}
+{"Action":"run","Package":"bench","Test":"BenchmarkJoin"}
+{"Action":"output","Package":"bench","Test":"BenchmarkJoin","Output":"=== RUN   BenchmarkJoin\n","OutputType":"frame"}
+{"Action":"output","Package":"bench","Test":"BenchmarkJoin","Output":"BenchmarkJoin\n"}
+{"Action":"output","Package":"bench","Test":"BenchmarkJoin","Output":"BenchmarkJoin-8   \t    1000\t        60.00 ns/op\t       8 B/op\t       1 allocs/op\n"}
+{"Action":"output","Package":"bench","Output":"BenchmarkJoin-8   \t    1000\t        50.00 ns/op\t       8 B/op\t       1 allocs/op\n"}
+{"Action":"run","Package":"bench","Test":"BenchmarkCustom/small"}
+{"Action":"output","Package":"bench","Test":"BenchmarkCustom/small","Output":"BenchmarkCustom/small-8         \t"}
+{"Action":"output","Package":"bench","Test":"BenchmarkCustom/small","Output":"    1000\t         2.000 ns/op\t        42.00 widgets/op\t       0 B/op\t       0 allocs/op\n"}
+{"Action":"output","Package":"bench","Output":"PASS\n","OutputType":"frame"}
+{"Action":"pass","Package":"bench","Elapsed":0.005}`
+
+func addOutput(t *testing.T, tm *TestManager, output string) {
+	t.Helper()
+
+	for _, line := range strings.Split(output, "\n") {
+		var testOutput TestOutputLine
+		require.NoError(t, json.Unmarshal([]byte(line), &testOutput))
+		tm.AddTestOutput(testOutput)
+	}
+}
+
+func TestGetBenchmarks(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, benchmarkOutput)
+
+	results := tm.GetBenchmarks()
+	require.Len(t, results, 2)
+
+	assert.Equal(t, TestReference{Package: "bench", Test: "BenchmarkCustom/small"}, results[0].Ref)
+	assert.Len(t, results[0].Samples, 1)
+	assert.Equal(t, []string{"ns/op", "widgets/op", "B/op", "allocs/op"}, results[0].Units())
+
+	assert.Equal(t, TestReference{Package: "bench", Test: "BenchmarkJoin"}, results[1].Ref)
+	assert.Len(t, results[1].Samples, 2)
+
+	mean, ok := results[1].Mean(outputparse.UnitNsPerOp)
+	assert.True(t, ok)
+	assert.Equal(t, 55.0, mean)
+
+	_, ok = results[1].Mean("widgets/op")
+	assert.False(t, ok)
+
+	// the partial benchmark line is joined before being logged
+	logs := tm.GetLogs(TestReference{Package: "bench", Test: "BenchmarkCustom/small"})
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0].Message, "BenchmarkCustom/small-8")
+	assert.Contains(t, logs[0].Message, "42.00 widgets/op")
+}
+
+func TestBenchmarksPassWithPackage(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, benchmarkOutput)
+
+	for _, test := range tm.GetTests {
+		assert.Equal(t, StatusPassed, test.Status, test.Ref.Test)
+	}
+}
+
+// from go test -json -bench . where BenchmarkB calls b.Fatal
+const failedBenchmarkOutput = `{"Time":"2025-01-01T10:00:00Z","Action":"start","Package":"bench"}
+{"Time":"2025-01-01T10:00:01Z","Action":"run","Package":"bench","Test":"BenchmarkA"}
+{"Time":"2025-01-01T10:00:01Z","Action":"output","Package":"bench","Test":"BenchmarkA","Output":"=== RUN   BenchmarkA\n","OutputType":"frame"}
+{"Time":"2025-01-01T10:00:01Z","Action":"output","Package":"bench","Test":"BenchmarkA","Output":"BenchmarkA\n"}
+{"Time":"2025-01-01T10:00:02Z","Action":"output","Package":"bench","Test":"BenchmarkA","Output":"BenchmarkA \t     100\t         7.300 ns/op\n"}
+{"Time":"2025-01-01T10:00:03Z","Action":"run","Package":"bench","Test":"BenchmarkB"}
+{"Time":"2025-01-01T10:00:03Z","Action":"output","Package":"bench","Test":"BenchmarkB","Output":"=== RUN   BenchmarkB\n","OutputType":"frame"}
+{"Time":"2025-01-01T10:00:03Z","Action":"output","Package":"bench","Test":"BenchmarkB","Output":"BenchmarkB\n"}
+{"Time":"2025-01-01T10:00:03Z","Action":"output","Package":"bench","Test":"BenchmarkB","Output":"    b_test.go:11: boom\n","OutputType":"error"}
+{"Time":"2025-01-01T10:00:03Z","Action":"output","Package":"bench","Test":"BenchmarkB","Output":"--- FAIL: BenchmarkB\n","OutputType":"frame"}
+{"Time":"2025-01-01T10:00:03Z","Action":"fail","Package":"bench","Test":"BenchmarkB"}
+{"Time":"2025-01-01T10:00:04Z","Action":"run","Package":"bench","Test":"BenchmarkC"}
+{"Time":"2025-01-01T10:00:04Z","Action":"output","Package":"bench","Test":"BenchmarkC","Output":"=== RUN   BenchmarkC\n","OutputType":"frame"}
+{"Time":"2025-01-01T10:00:04Z","Action":"output","Package":"bench","Test":"BenchmarkC","Output":"BenchmarkC\n"}
+{"Time":"2025-01-01T10:00:05Z","Action":"output","Package":"bench","Test":"BenchmarkC","Output":"BenchmarkC \t     100\t         4.190 ns/op\n"}
+{"Time":"2025-01-01T10:00:05Z","Action":"output","Package":"bench","Output":"FAIL\n","OutputType":"frame"}
+{"Time":"2025-01-01T10:00:05Z","Action":"output","Package":"bench","Output":"exit status 1\n"}
+{"Time":"2025-01-01T10:00:05Z","Action":"output","Package":"bench","Output":"FAIL\tbench\t0.005s\n","OutputType":"frame"}
+{"Time":"2025-01-01T10:00:06Z","Action":"fail","Package":"bench","Elapsed":0.005}`
+
+func benchmarkStatuses(tm *TestManager) map[string]TestStatus {
+	statuses := make(map[string]TestStatus)
+	for _, test := range tm.GetTests {
+		statuses[test.Ref.Test] = test.Status
+	}
+
+	return statuses
+}
+
+func TestBenchmarksPassWithResult(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	lines := strings.Split(failedBenchmarkOutput, "\n")
+
+	// the benchmark passes once its result is written, before the package ends
+	addOutput(t, tm, strings.Join(lines[:5], "\n"))
+	test := tm.GetTest(0)
+	assert.Equal(t, StatusPassed, test.Status)
+	assert.Equal(t, time.Second, test.Elapsed)
+
+	// the benchmarks which succeeded aren't aborted when another fails
+	addOutput(t, tm, strings.Join(lines[5:], "\n"))
+	assert.Equal(t, map[string]TestStatus{
+		"BenchmarkA": StatusPassed,
+		"BenchmarkB": StatusFailed,
+		"BenchmarkC": StatusPassed,
+	}, benchmarkStatuses(tm))
+}
+
+func TestBenchmarksPassWithNextBenchmark(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, `{"Time":"2025-01-01T10:00:00Z","Action":"run","Package":"bench","Test":"BenchmarkA"}
+{"Time":"2025-01-01T10:00:01Z","Action":"run","Package":"bench","Test":"BenchmarkA/small"}
+{"Time":"2025-01-01T10:00:02Z","Action":"output","Package":"bench","Test":"BenchmarkA/small","Output":"BenchmarkA/small-8 \t     100\t         7.300 ns/op\n"}
+{"Time":"2025-01-01T10:00:02Z","Action":"run","Package":"bench","Test":"BenchmarkA/large"}`)
+
+	// BenchmarkA is still running its sub-benchmarks
+	assert.Equal(t, map[string]TestStatus{
+		"BenchmarkA":       StatusRunning,
+		"BenchmarkA/small": StatusPassed,
+		"BenchmarkA/large": StatusRunning,
+	}, benchmarkStatuses(tm))
+
+	addOutput(t, tm, `{"Time":"2025-01-01T10:00:03Z","Action":"output","Package":"bench","Test":"BenchmarkA/large","Output":"BenchmarkA/large-8 \t     100\t         9.100 ns/op\n"}
+{"Time":"2025-01-01T10:00:04Z","Action":"run","Package":"bench","Test":"BenchmarkB"}`)
+
+	assert.Equal(t, map[string]TestStatus{
+		"BenchmarkA":       StatusPassed,
+		"BenchmarkA/small": StatusPassed,
+		"BenchmarkA/large": StatusPassed,
+		"BenchmarkB":       StatusRunning,
+	}, benchmarkStatuses(tm))
+}
+
+func TestDiffBenchmarks(t *testing.T) {
+	sample := func(name string, ns float64) outputparse.Benchmark {
+		return outputparse.Benchmark{
+			Name:    name,
+			Metrics: []outputparse.BenchmarkMetric{{Value: ns, Unit: outputparse.UnitNsPerOp}},
+		}
+	}
+
+	old := []BenchmarkResult{
+		{
+			Ref:     TestReference{Package: "bench", Test: "BenchmarkJoin"},
+			Samples: []outputparse.Benchmark{sample("BenchmarkJoin", 100), sample("BenchmarkJoin", 110)},
+		},
+		{
+			Ref:     TestReference{Package: "bench", Test: "BenchmarkRemoved"},
+			Samples: []outputparse.Benchmark{sample("BenchmarkRemoved", 100)},
+		},
+	}
+
+	new := []BenchmarkResult{
+		{
+			Ref:     TestReference{Package: "bench", Test: "BenchmarkJoin"},
+			Samples: []outputparse.Benchmark{sample("BenchmarkJoin", 200), sample("BenchmarkJoin", 220)},
+		},
+		{
+			Ref:     TestReference{Package: "bench", Test: "BenchmarkAdded"},
+			Samples: []outputparse.Benchmark{sample("BenchmarkAdded", 100)},
+		},
+	}
+
+	diffs := DiffBenchmarks(old, new)
+	require.Len(t, diffs, 1)
+
+	assert.Equal(t, TestReference{Package: "bench", Test: "BenchmarkJoin"}, diffs[0].Ref)
+	require.Len(t, diffs[0].Metrics, 1)
+	assert.Equal(t, outputparse.UnitNsPerOp, diffs[0].Metrics[0].Unit)
+	assert.Equal(t, 105.0, diffs[0].Metrics[0].OldMean)
+	assert.Equal(t, 210.0, diffs[0].Metrics[0].NewMean)
+	assert.Equal(t, 100.0, diffs[0].Metrics[0].Delta)
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"strings"
+
+	"github.com/timtatt/sift/pkg/logparse"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// isDiagnosticContinuation checks if the build output continues the previous
+// diagnostic, such as the have and want of a mismatched call
+func isDiagnosticContinuation(testOutput TestOutputLine, line string) bool {
+	return testOutput.Action == "build-output" && strings.HasPrefix(line, "\t")
+}
+
+// isBuildHeader checks for the line naming the package before its build
+// output, eg. # foo or # foo [foo.test]
+func isBuildHeader(testOutput TestOutputLine, line string) bool {
+	return testOutput.Action == "build-output" && strings.HasPrefix(line, "# ")
+}
+
+// isVetHeader checks for the header written by vet, eg. # [foo]
+func isVetHeader(line string) bool {
+	pkg, ok := strings.CutPrefix(line, "# [")
+	return ok && strings.HasSuffix(pkg, "]")
+}
+
+// buildDiagnostics parses the build output of a package into diagnostics
+func (tm *TestManager) buildDiagnostics(testRef TestReference) []outputparse.Diagnostic {
+	tm.testLogLock.RLock()
+	defer tm.testLogLock.RUnlock()
+
+	var diagnostics []outputparse.Diagnostic
+	for _, log := range tm.testLogs[testRef] {
+		if diagnostic, ok := LogDiagnostic(log); ok {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	return diagnostics
+}
+
+// LogDiagnostic parses a log of the build output as a diagnostic, including
+// the lines which continue it
+func LogDiagnostic(log logparse.LogEntry) (outputparse.Diagnostic, bool) {
+	diagnostic, ok := outputparse.ParseDiagnostic(log.Message)
+	if !ok {
+		return outputparse.Diagnostic{}, false
+	}
+
+	for _, line := range log.Continuation {
+		diagnostic.Message += "\n" + strings.TrimSpace(line)
+	}
+
+	return diagnostic, true
+}
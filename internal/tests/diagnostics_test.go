@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+const buildFailureOutput = `{"ImportPath":"example [example.test]","Action":"build-output","Output":"# example [example.test]\n"}
+{"ImportPath":"example [example.test]","Action":"build-output","Output":"./foo.go:3:23: undefined: Bar\n"}
+{"ImportPath":"example [example.test]","Action":"build-output","Output":"./foo_test.go:6:10: not enough arguments in call to Foo\n"}
+{"ImportPath":"example [example.test]","Action":"build-output","Output":"\thave ()\n"}
+{"ImportPath":"example [example.test]","Action":"build-output","Output":"\twant (int)\n"}
+{"ImportPath":"example [example.test]","Action":"build-output","Output":"too many errors\n"}
+{"ImportPath":"example [example.test]","Action":"build-fail"}`
+
+func TestBuildDiagnostics(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, buildFailureOutput)
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Equal(t, StatusBuildError, test.Status)
+
+	assert.Equal(t, []outputparse.Diagnostic{
+		{File: "./foo.go", Line: 3, Column: 23, Message: "undefined: Bar"},
+		{File: "./foo_test.go", Line: 6, Column: 10, Message: "not enough arguments in call to Foo\nhave ()\nwant (int)"},
+	}, test.Diagnostics)
+
+	// each diagnostic is a single log, including the lines which continue it
+	logs := tm.GetLogs(test.Ref)
+	require.Len(t, logs, 3)
+	assert.Equal(t, []string{"\thave ()", "\twant (int)"}, logs[1].Continuation)
+	assert.Equal(t, "too many errors", logs[2].Message)
+}
+
+const vetFailureOutput = `{"ImportPath":"example [example.test]","Action":"build-output","Output":"# example\n"}
+{"ImportPath":"example [example.test]","Action":"build-output","Output":"# [example]\n"}
+{"ImportPath":"example [example.test]","Action":"build-output","Output":"./foo_test.go:9:14: fmt.Printf format %d has arg \"str\" of wrong type string\n"}
+{"ImportPath":"example [example.test]","Action":"build-fail"}`
+
+func TestVetDiagnostics(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, vetFailureOutput)
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Equal(t, StatusVetError, test.Status)
+	assert.Equal(t, StatusVetError, tm.GetPackage("example [example.test]").Status)
+
+	assert.Equal(t, []outputparse.Diagnostic{
+		{File: "./foo_test.go", Line: 9, Column: 14, Message: "fmt.Printf format %d has arg \"str\" of wrong type string"},
+	}, test.Diagnostics)
+
+	// the headers aren't included in the logs
+	logs := tm.GetLogs(test.Ref)
+	require.Len(t, logs, 1)
+}
@@ -0,0 +1,28 @@
+package tests
+
+import (
+	"time"
+)
+
+// Durations are how long each test took the last time it ran, keyed by the
+// package and then the test. They are used to estimate how long a run will
+// take
+type Durations map[string]map[string]time.Duration
+
+func (d Durations) Get(ref TestReference) (time.Duration, bool) {
+	duration, ok := d[ref.Package][ref.Test]
+	return duration, ok
+}
+
+// SetDefault records the duration of the test, unless one is already known
+func (d Durations) SetDefault(ref TestReference, duration time.Duration) {
+	if _, ok := d.Get(ref); ok {
+		return
+	}
+
+	if d[ref.Package] == nil {
+		d[ref.Package] = make(map[string]time.Duration)
+	}
+
+	d[ref.Package][ref.Test] = duration
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// numbers are left out of a fingerprint, as line numbers, addresses and
+// timings change between runs without the failure changing
+var fingerprintNumberRegex = regexp.MustCompile(`0x[0-9a-fA-F]+|\d+`)
+
+// GetFailureFingerprint identifies why the test failed, so the same failure
+// can be recognised across runs. it's empty when the test didn't fail, or
+// only failed because of its subtests
+func (tm *TestManager) GetFailureFingerprint(test *TestNode) string {
+	if !test.Status.Failure() {
+		return ""
+	}
+
+	var cause []string
+	switch {
+	case len(test.Diagnostics) > 0:
+		for _, diagnostic := range test.Diagnostics {
+			cause = append(cause, diagnostic.File+": "+diagnostic.Message)
+		}
+	case test.Panic != nil:
+		cause = append(cause, "panic: "+test.Panic.Message)
+	case len(test.Races) > 0:
+		for _, access := range test.Races[0].Accesses {
+			if len(access.Frames) > 0 {
+				cause = append(cause, "race: "+access.Kind+" "+access.Frames[0].Function)
+			}
+		}
+	default:
+		for _, log := range tm.GetLogs(test.Ref) {
+			if log.IsError() {
+				cause = append(cause, log.Lines()...)
+			}
+		}
+	}
+
+	if len(cause) == 0 {
+		return ""
+	}
+
+	normalised := fingerprintNumberRegex.ReplaceAllString(strings.Join(cause, "\n"), "#")
+	sum := sha256.Sum256([]byte(normalised))
+
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFailureFingerprint(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{ParseLogs: true})
+	addOutput(t, tm, `{"Action":"run","Package":"pkg","Test":"TestA"}
+{"Action":"output","Package":"pkg","Test":"TestA","Output":"    foo_test.go:10: got 1, want 2\n","OutputType":"error"}
+{"Action":"fail","Package":"pkg","Test":"TestA"}
+{"Action":"run","Package":"pkg","Test":"TestB"}
+{"Action":"output","Package":"pkg","Test":"TestB","Output":"    foo_test.go:24: got 3, want 4\n","OutputType":"error"}
+{"Action":"fail","Package":"pkg","Test":"TestB"}
+{"Action":"run","Package":"pkg","Test":"TestC"}
+{"Action":"output","Package":"pkg","Test":"TestC","Output":"    foo_test.go:30: unexpected error\n","OutputType":"error"}
+{"Action":"fail","Package":"pkg","Test":"TestC"}
+{"Action":"run","Package":"pkg","Test":"TestD"}
+{"Action":"pass","Package":"pkg","Test":"TestD"}`)
+
+	a := tm.GetFailureFingerprint(tm.GetTest(0))
+	assert.Len(t, a, 16)
+
+	// the numbers in the failure don't change its fingerprint
+	assert.Equal(t, a, tm.GetFailureFingerprint(tm.GetTest(1)))
+	assert.NotEqual(t, a, tm.GetFailureFingerprint(tm.GetTest(2)))
+
+	assert.Empty(t, tm.GetFailureFingerprint(tm.GetTest(3)))
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// FuzzStatus tracks the progress of a fuzz target running with `go test -fuzz`
+type FuzzStatus struct {
+	Progress outputparse.FuzzProgress
+
+	// FailingInput is the path of the corpus file written when the target fails
+	FailingInput string
+}
+
+// ReproduceCommand builds the command to re-run the fuzz target with the failing input
+func (fs FuzzStatus) ReproduceCommand(ref TestReference) string {
+	if fs.FailingInput == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("go test -run=%s/%s %s", ref.Test, filepath.Base(fs.FailingInput), ref.Package)
+}
+
+// update merges the latest progress, keeping the previous execs while the
+// fuzzer is reporting a phase such as minimizing
+func (fs *FuzzStatus) update(progress outputparse.FuzzProgress) {
+	if progress.Phase != "" {
+		progress.Execs = fs.Progress.Execs
+		progress.ExecsPerSec = fs.Progress.ExecsPerSec
+		progress.NewInteresting = fs.Progress.NewInteresting
+		progress.TotalInteresting = fs.Progress.TotalInteresting
+		progress.Elapsed = max(progress.Elapsed, fs.Progress.Elapsed)
+	}
+
+	fs.Progress = progress
+}
+
+// addFuzzOutput records fuzzing progress and failing inputs against the test.
+// Returns true when the line is a progress update which shouldn't be logged
+func (tm *TestManager) addFuzzOutput(testRef TestReference, line string) bool {
+	progress, isProgress := outputparse.ParseFuzzProgress(line)
+	failingInput, isFailingInput := outputparse.ParseFuzzFailingInput(line)
+
+	if !isProgress && !isFailingInput {
+		return false
+	}
+
+	tm.testLock.Lock()
+	defer tm.testLock.Unlock()
+
+	test := tm.findTest(testRef)
+	if test == nil {
+		return isProgress
+	}
+
+	// replace the status rather than modifying it, since it may be read while rendering
+	var status FuzzStatus
+	if test.Fuzz != nil {
+		status = *test.Fuzz
+	}
+
+	if isProgress {
+		status.update(progress)
+	} else {
+		status.FailingInput = failingInput
+	}
+
+	test.Fuzz = &status
+
+	return isProgress
+}
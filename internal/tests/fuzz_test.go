@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fuzzOutput = `{"Action":"start","Package":"fuzz"}
+{"Action":"run","Package":"fuzz","Test":"FuzzReverse"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"=== RUN   FuzzReverse\n","OutputType":"frame"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"fuzz: elapsed: 0s, gathering baseline coverage: 0/2 completed\n"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"fuzz: elapsed: 3s, execs: 102343 (34074/sec), new interesting: 1 (total: 3)\n"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"fuzz: minimizing 31-byte failing input file\n"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"--- FAIL: FuzzReverse (3.13s)\n","OutputType":"frame"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"        f_test.go:9: bad input \"x000\"\n"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"    Failing input written to testdata/fuzz/FuzzReverse/1de061fa29cfbb3d\n"}
+{"Action":"fail","Package":"fuzz","Test":"FuzzReverse","Elapsed":3.13}
+{"Action":"fail","Package":"fuzz","Elapsed":3.134}`
+
+func TestFuzzStatus(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, fuzzOutput)
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	require.NotNil(t, test.Fuzz)
+
+	assert.Equal(t, StatusFailed, test.Status)
+	assert.Equal(t, 3*time.Second, test.Fuzz.Progress.Elapsed)
+	assert.Equal(t, int64(102343), test.Fuzz.Progress.Execs)
+	assert.Equal(t, int64(34074), test.Fuzz.Progress.ExecsPerSec)
+	assert.Equal(t, 1, test.Fuzz.Progress.NewInteresting)
+	assert.Equal(t, 3, test.Fuzz.Progress.TotalInteresting)
+	assert.Equal(t, "minimizing 31-byte failing input file", test.Fuzz.Progress.Phase)
+
+	assert.Equal(t, "testdata/fuzz/FuzzReverse/1de061fa29cfbb3d", test.Fuzz.FailingInput)
+	assert.Equal(t, "go test -run=FuzzReverse/1de061fa29cfbb3d fuzz", test.Fuzz.ReproduceCommand(test.Ref))
+
+	// progress lines are left out of the logs
+	var logs []string
+	for _, log := range tm.GetLogs(test.Ref) {
+		logs = append(logs, log.Message)
+	}
+	assert.Equal(t, []string{
+		`        f_test.go:9: bad input "x000"`,
+		"    Failing input written to testdata/fuzz/FuzzReverse/1de061fa29cfbb3d",
+	}, logs)
+}
+
+func TestFuzzStatus_NotFuzzing(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, `{"Action":"run","Package":"fuzz","Test":"FuzzReverse"}
+{"Action":"output","Package":"fuzz","Test":"FuzzReverse","Output":"=== RUN   FuzzReverse/seed#0\n"}
+{"Action":"pass","Package":"fuzz","Test":"FuzzReverse","Elapsed":0}`)
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Nil(t, test.Fuzz)
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// addPanicOutput collects the output of a test from the `panic:` line onwards,
+// since everything after it is part of the panic's goroutine stacks.
+// Returns true when the line is part of a panic and shouldn't be logged
+func (tm *TestManager) addPanicOutput(testRef TestReference, line string) bool {
+	// panics outside of a test are left in the package logs
+	if testRef.Test == "" {
+		return false
+	}
+
+	if lines, ok := tm.panicOutput[testRef]; ok {
+		tm.panicOutput[testRef] = append(lines, line)
+		return true
+	}
+
+	if !outputparse.IsPanic(line) {
+		return false
+	}
+
+	tm.panicOutput[testRef] = []string{line}
+
+	return true
+}
+
+// finishPanic parses the collected panic output and attaches it to the test.
+// must be called while holding the testLock
+func (tm *TestManager) finishPanic(test *TestNode) {
+	lines, ok := tm.panicOutput[test.Ref]
+	if !ok {
+		return
+	}
+
+	delete(tm.panicOutput, test.Ref)
+
+	if p, ok := outputparse.ParsePanic(lines); ok {
+		test.Panic = &p
+	}
+}
+
+// abortTests finishes the tests of a package which exited while they were
+// still running. tests which panicked, and their parents, have failed. when
+// the test binary timed out, the tests which were running have timed out.
+// the rest are aborted. must be called while holding the testLock
+func (tm *TestManager) abortTests(pkg string, t time.Time) {
+	var panicked []string
+	timedOut := make(map[string]time.Duration)
+
+	for _, test := range tm.tests {
+		if test.Ref.Package != pkg {
+			continue
+		}
+
+		tm.finishPanic(test)
+		if test.Panic == nil {
+			continue
+		}
+
+		if !test.Panic.IsTimeout() {
+			panicked = append(panicked, test.Ref.Test)
+			continue
+		}
+
+		for _, running := range test.Panic.RunningTests {
+			timedOut[running.Name] = running.Elapsed
+		}
+	}
+
+	for _, test := range tm.tests {
+		if test.Ref.Package != pkg || !test.Status.Active() {
+			continue
+		}
+
+		action := "abort"
+		if isTestOrParent(test.Ref.Test, panicked) {
+			action = "fail"
+		} else if isTestOrParent(test.Ref.Test, slices.Collect(maps.Keys(timedOut))) {
+			action = "timeout"
+		}
+
+		if elapsed, ok := timedOut[test.Ref.Test]; ok {
+			test.Elapsed = elapsed
+		} else if !test.StartTime.IsZero() {
+			test.Elapsed = t.Sub(test.StartTime)
+		}
+
+		test.Status = test.Status.Transition(action)
+		test.EndTime = t
+		test.endSpan(t)
+	}
+}
+
+// isTestOrParent checks if the test is one of the names, or a parent of one
+func isTestOrParent(testName string, names []string) bool {
+	for _, name := range names {
+		if name == testName || strings.HasPrefix(name, testName+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// a goroutine started by TestPanics panics while TestSlow and TestPanics/sub are still running
+const panicOutput = `{"Action":"start","Package":"example"}
+{"Action":"run","Package":"example","Test":"TestSlow"}
+{"Action":"run","Package":"example","Test":"TestPanics"}
+{"Action":"run","Package":"example","Test":"TestPanics/sub"}
+{"Action":"run","Package":"example","Test":"TestPassed"}
+{"Action":"pass","Package":"example","Test":"TestPassed","Elapsed":0}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"    foo_test.go:12: about to panic\n"}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"panic: boom\n"}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"\n"}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"goroutine 9 [running]:\n"}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"example.TestPanics.func1.1()\n"}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"\t/src/example/foo_test.go:16 +0x25\n"}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"created by example.TestPanics.func1 in goroutine 8\n"}
+{"Action":"output","Package":"example","Test":"TestPanics/sub","Output":"\t/src/example/foo_test.go:15 +0x1f\n"}
+{"Action":"output","Package":"example","Output":"FAIL\texample\t0.206s\n","OutputType":"frame"}
+{"Action":"fail","Package":"example","Elapsed":0.207}`
+
+func TestPanic_PackageExit(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, panicOutput)
+
+	statuses := make(map[string]TestStatus)
+	for _, test := range tm.GetTests {
+		statuses[test.Ref.Test] = test.Status
+	}
+
+	assert.Equal(t, map[string]TestStatus{
+		"TestPanics":     StatusFailed,
+		"TestPanics/sub": StatusFailed,
+		"TestPassed":     StatusPassed,
+		"TestSlow":       StatusAborted,
+	}, statuses)
+
+	sub := tm.GetTest(1)
+	require.Equal(t, "TestPanics/sub", sub.Ref.Test)
+	require.NotNil(t, sub.Panic)
+	assert.Equal(t, "boom", sub.Panic.Message)
+	require.Len(t, sub.Panic.Goroutines, 1)
+	assert.Len(t, sub.Panic.Goroutines[0].Frames, 2)
+
+	// only the output before the panic is logged
+	logs := tm.GetLogs(sub.Ref)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "    foo_test.go:12: about to panic", logs[0].Message)
+}
+
+func TestPanic_TestFails(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, `{"Action":"run","Package":"example","Test":"TestPanics"}
+{"Action":"output","Package":"example","Test":"TestPanics","Output":"--- FAIL: TestPanics (0.05s)\n","OutputType":"frame"}
+{"Action":"output","Package":"example","Test":"TestPanics","Output":"panic: assignment to entry in nil map [recovered, repanicked]\n"}
+{"Action":"output","Package":"example","Test":"TestPanics","Output":"\n"}
+{"Action":"output","Package":"example","Test":"TestPanics","Output":"goroutine 10 [running]:\n"}
+{"Action":"output","Package":"example","Test":"TestPanics","Output":"example.TestPanics(0x103647268908?)\n"}
+{"Action":"output","Package":"example","Test":"TestPanics","Output":"\t/src/example/foo_test.go:22 +0x33\n"}
+{"Action":"fail","Package":"example","Test":"TestPanics","Elapsed":0.05}`)
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Equal(t, StatusFailed, test.Status)
+	require.NotNil(t, test.Panic)
+	assert.Equal(t, "assignment to entry in nil map [recovered, repanicked]", test.Panic.Message)
+	assert.Empty(t, tm.GetLogs(test.Ref))
+}
+
+func TestPanic_Timeout(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, `{"Action":"start","Package":"example"}
+{"Action":"run","Package":"example","Test":"TestDeadlock"}
+{"Action":"run","Package":"example","Test":"TestStuck"}
+{"Action":"run","Package":"example","Test":"TestStuck/inner"}
+{"Action":"run","Package":"example","Test":"TestWaiting"}
+{"Action":"pause","Package":"example","Test":"TestWaiting"}
+{"Action":"output","Package":"example","Test":"TestStuck/inner","Output":"panic: test timed out after 1s\n"}
+{"Action":"output","Package":"example","Test":"TestStuck/inner","Output":"\trunning tests:\n"}
+{"Action":"output","Package":"example","Test":"TestStuck/inner","Output":"\t\tTestDeadlock (1s)\n"}
+{"Action":"output","Package":"example","Test":"TestStuck/inner","Output":"\t\tTestStuck/inner (900ms)\n"}
+{"Action":"output","Package":"example","Test":"TestStuck/inner","Output":"\n"}
+{"Action":"output","Package":"example","Test":"TestStuck/inner","Output":"goroutine 13 [running]:\n"}
+{"Action":"output","Package":"example","Output":"FAIL\texample\t1.007s\n","OutputType":"frame"}
+{"Action":"fail","Package":"example","Elapsed":1.007}`)
+
+	statuses := make(map[string]TestStatus)
+	for _, test := range tm.GetTests {
+		statuses[test.Ref.Test] = test.Status
+	}
+
+	assert.Equal(t, map[string]TestStatus{
+		"TestDeadlock":    StatusTimedOut,
+		"TestStuck":       StatusTimedOut,
+		"TestStuck/inner": StatusTimedOut,
+		"TestWaiting":     StatusAborted,
+	}, statuses)
+
+	inner := tm.GetTest(2)
+	require.Equal(t, "TestStuck/inner", inner.Ref.Test)
+	assert.Equal(t, 900*time.Millisecond, inner.Elapsed)
+	require.NotNil(t, inner.Panic)
+	assert.True(t, inner.Panic.IsTimeout())
+}
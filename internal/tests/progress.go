@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"time"
+)
+
+// Progress is how many of the top level tests have finished. subtests aren't
+// counted as they aren't known until their parent runs
+type Progress struct {
+	Done  int
+	Total int
+
+	// Expected is how long the tests took in previous runs, and ExpectedDone
+	// how much of that belongs to the tests which have finished. both are 0
+	// when none of the tests have run before
+	Expected     time.Duration
+	ExpectedDone time.Duration
+}
+
+func (p Progress) Percent() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+
+	return float64(p.Done) / float64(p.Total)
+}
+
+// ETA estimates how much longer the run will take, assuming the remaining
+// tests run at the same pace relative to previous runs as the finished tests
+// did. there is no estimate until a test with a previous duration finishes
+func (p Progress) ETA(elapsed time.Duration) (time.Duration, bool) {
+	if p.ExpectedDone <= 0 {
+		return 0, false
+	}
+
+	pace := float64(elapsed) / float64(p.ExpectedDone)
+
+	return time.Duration(float64(p.Expected-p.ExpectedDone) * pace), true
+}
+
+// GetProgress counts the finished top level tests, weighting them by their
+// previous durations to estimate the time remaining. tests which haven't run
+// before are expected to take the average of those which have
+func (tm *TestManager) GetProgress(durations Durations) Progress {
+	tm.testLock.RLock()
+	defer tm.testLock.RUnlock()
+
+	var (
+		progress Progress
+		known    time.Duration
+		knownN   int
+	)
+
+	for _, test := range tm.tests {
+		if _, isSubtest := test.Ref.Parent(); isSubtest || test.Ref.Test == "" {
+			continue
+		}
+
+		progress.Total++
+		if test.Status.Done() {
+			progress.Done++
+		}
+
+		if duration, ok := durations.Get(test.Ref); ok {
+			known += duration
+			knownN++
+		}
+	}
+
+	if knownN == 0 {
+		return progress
+	}
+
+	average := known / time.Duration(knownN)
+
+	for _, test := range tm.tests {
+		if _, isSubtest := test.Ref.Parent(); isSubtest || test.Ref.Test == "" {
+			continue
+		}
+
+		duration, ok := durations.Get(test.Ref)
+		if !ok {
+			duration = average
+		}
+
+		progress.Expected += duration
+		if test.Status.Done() {
+			progress.ExpectedDone += duration
+		}
+	}
+
+	return progress
+}
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProgress(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	tm.QueueTests("pkg", []string{"TestA", "TestB", "TestC", "TestD"})
+
+	addOutput(t, tm, `{"Time":"2025-01-01T10:00:00Z","Action":"run","Package":"pkg","Test":"TestA"}
+{"Time":"2025-01-01T10:00:01Z","Action":"run","Package":"pkg","Test":"TestA/one"}
+{"Time":"2025-01-01T10:00:02Z","Action":"pass","Package":"pkg","Test":"TestA/one"}
+{"Time":"2025-01-01T10:00:03Z","Action":"pass","Package":"pkg","Test":"TestA"}
+{"Time":"2025-01-01T10:00:04Z","Action":"run","Package":"pkg","Test":"TestB"}`)
+
+	progress := tm.GetProgress(Durations{})
+	assert.Equal(t, Progress{Done: 1, Total: 4}, progress)
+	assert.Equal(t, 0.25, progress.Percent())
+
+	_, ok := progress.ETA(time.Second)
+	assert.False(t, ok)
+
+	// TestD hasn't run before, so it's expected to take the average
+	progress = tm.GetProgress(Durations{"pkg": {
+		"TestA": 2 * time.Second,
+		"TestB": 4 * time.Second,
+		"TestC": 6 * time.Second,
+	}})
+	assert.Equal(t, 16*time.Second, progress.Expected)
+	assert.Equal(t, 2*time.Second, progress.ExpectedDone)
+
+	// the run is going half as fast as previous runs
+	eta, ok := progress.ETA(4 * time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 28*time.Second, eta)
+}
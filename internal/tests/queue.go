@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"slices"
+)
+
+// QueueTests adds the tests listed by `go test -list` for the package, so they
+// are shown before they run. tests which have already started and packages
+// which have already finished are left as they are
+func (tm *TestManager) QueueTests(pkg string, names []string) {
+	tm.testLock.Lock()
+	defer tm.testLock.Unlock()
+
+	if pkgNode := tm.getPackage(pkg); pkgNode.Status.Done() {
+		return
+	}
+
+	for _, name := range names {
+		testRef := TestReference{Package: pkg, Test: name}
+		if tm.findTest(testRef) != nil {
+			continue
+		}
+
+		tm.insertTest(&TestNode{
+			Ref:    testRef,
+			Status: StatusQueued,
+		})
+	}
+}
+
+// insertTest adds the test in the order of the tree. must be called while
+// holding the testLock
+func (tm *TestManager) insertTest(test *TestNode) {
+	insertIdx := len(tm.tests)
+	for i, t := range tm.tests {
+		if CompareTestNode(test, t) < 0 {
+			insertIdx = i
+			break
+		}
+	}
+
+	tm.tests = slices.Insert(tm.tests, insertIdx, test)
+}
+
+// dropQueuedTests removes the tests of the package which were listed but never
+// ran, eg. because they were filtered out by -run. must be called while
+// holding the testLock
+func (tm *TestManager) dropQueuedTests(pkg string) {
+	tm.tests = slices.DeleteFunc(tm.tests, func(test *TestNode) bool {
+		return test.Ref.Package == pkg && test.Status == StatusQueued
+	})
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStatuses(tm *TestManager) map[string]TestStatus {
+	statuses := make(map[string]TestStatus)
+	for _, test := range tm.GetTests {
+		statuses[test.Ref.Test] = test.Status
+	}
+
+	return statuses
+}
+
+func TestQueueTests(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	tm.QueueTests("pkg", []string{"TestB", "TestA", "TestC"})
+
+	require.Equal(t, 3, tm.GetTestCount())
+	assert.Equal(t, "TestA", tm.GetTest(0).Ref.Test)
+	assert.Equal(t, StatusQueued, tm.GetPackage("pkg").Status)
+
+	addOutput(t, tm, `{"Time":"2025-01-01T10:00:00Z","Action":"start","Package":"pkg"}
+{"Time":"2025-01-01T10:00:01Z","Action":"run","Package":"pkg","Test":"TestA"}
+{"Time":"2025-01-01T10:00:02Z","Action":"run","Package":"pkg","Test":"TestA/one"}`)
+
+	// the queued test is started rather than added again
+	require.Equal(t, 4, tm.GetTestCount())
+	assert.Equal(t, map[string]TestStatus{
+		"TestA":     StatusRunning,
+		"TestA/one": StatusRunning,
+		"TestB":     StatusQueued,
+		"TestC":     StatusQueued,
+	}, testStatuses(tm))
+	assert.False(t, tm.GetTest(0).StartTime.IsZero())
+
+	addOutput(t, tm, `{"Time":"2025-01-01T10:00:03Z","Action":"pass","Package":"pkg","Test":"TestA/one"}
+{"Time":"2025-01-01T10:00:04Z","Action":"pass","Package":"pkg","Test":"TestA"}
+{"Time":"2025-01-01T10:00:05Z","Action":"run","Package":"pkg","Test":"TestB"}
+{"Time":"2025-01-01T10:00:06Z","Action":"pass","Package":"pkg","Test":"TestB"}
+{"Time":"2025-01-01T10:00:07Z","Action":"pass","Package":"pkg"}`)
+
+	// TestC was filtered out of the run, so it's dropped once the package ends
+	assert.Equal(t, map[string]TestStatus{
+		"TestA":     StatusPassed,
+		"TestA/one": StatusPassed,
+		"TestB":     StatusPassed,
+	}, testStatuses(tm))
+
+	// a list which arrives after the package finished is ignored
+	tm.QueueTests("pkg", []string{"TestC"})
+	assert.Equal(t, 3, tm.GetTestCount())
+}
+
+func TestQueueTests_BuildFailed(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	tm.QueueTests("pkg", []string{"TestA"})
+
+	addOutput(t, tm, `{"Time":"2025-01-01T10:00:00Z","Action":"build-fail","ImportPath":"pkg"}`)
+
+	require.Equal(t, 1, tm.GetTestCount())
+	assert.Equal(t, StatusBuildError, tm.GetTest(0).Status)
+}
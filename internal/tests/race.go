@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"time"
+
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// addRaceOutput collects the data race reports written by the race detector,
+// which are surrounded by separators. Returns true when the line is part of a
+// report and shouldn't be logged
+func (tm *TestManager) addRaceOutput(testRef TestReference, testOutput TestOutputLine, line string) bool {
+	// races outside of a test are left in the package logs
+	if testRef.Test == "" {
+		return false
+	}
+
+	lines, ok := tm.raceOutput[testRef]
+	if !ok {
+		if line != outputparse.RaceSeparator {
+			return false
+		}
+
+		tm.raceOutput[testRef] = []string{}
+		return true
+	}
+
+	// the separator wasn't the start of a race report, so it's logged after all
+	if len(lines) == 0 && !outputparse.IsRaceWarning(line) {
+		tm.flushRaceOutput(testRef, testOutput.Time)
+		return false
+	}
+
+	if line != outputparse.RaceSeparator {
+		tm.raceOutput[testRef] = append(lines, line)
+		return true
+	}
+
+	delete(tm.raceOutput, testRef)
+
+	race, ok := outputparse.ParseRace(lines)
+	if !ok {
+		return true
+	}
+
+	tm.testLock.Lock()
+	defer tm.testLock.Unlock()
+
+	if test := tm.findTest(testRef); test != nil {
+		test.Races = append(test.Races, race)
+	}
+
+	return true
+}
+
+// flushRaceOutput logs the output held back for a race report which was never
+// finished, such as a separator written as the last line of a test. When the
+// package ends the output of each of its tests is flushed
+func (tm *TestManager) flushRaceOutput(testRef TestReference, t time.Time) {
+	for ref, lines := range tm.raceOutput {
+		if ref != testRef && (testRef.Test != "" || ref.Package != testRef.Package) {
+			continue
+		}
+
+		delete(tm.raceOutput, ref)
+
+		output := TestOutputLine{Time: t, Action: "output", Package: ref.Package, Test: ref.Test}
+		for _, line := range append([]string{outputparse.RaceSeparator}, lines...) {
+			tm.addLog(ref, output, line)
+		}
+	}
+}
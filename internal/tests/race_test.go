@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const raceOutput = `{"Action":"run","Package":"example","Test":"TestRace"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"==================\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"WARNING: DATA RACE\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"Read at 0x00c0000182b8 by goroutine 9:\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"  example.TestRace.func1()\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"      /src/example/race_test.go:12 +0x33\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"Previous write at 0x00c0000182b8 by goroutine 10:\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"  example.TestRace.func2()\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"      /src/example/race_test.go:13 +0x45\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"==================\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"    race_test.go:15: 2\n"}
+{"Action":"output","Package":"example","Test":"TestRace","Output":"    testing.go:1865: race detected during execution of test\n","OutputType":"error"}
+{"Action":"fail","Package":"example","Test":"TestRace","Elapsed":0}`
+
+func TestRaceReports(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, raceOutput)
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Equal(t, StatusFailed, test.Status)
+
+	require.Len(t, test.Races, 1)
+	require.Len(t, test.Races[0].Accesses, 2)
+	assert.Equal(t, "Read", test.Races[0].Accesses[0].Kind)
+	assert.Equal(t, "Previous write", test.Races[0].Accesses[1].Kind)
+
+	// the report is left out of the logs
+	var logs []string
+	for _, log := range tm.GetLogs(test.Ref) {
+		logs = append(logs, log.Message)
+	}
+	assert.Equal(t, []string{
+		"    race_test.go:15: 2",
+		"    testing.go:1865: race detected during execution of test",
+	}, logs)
+}
+
+func TestRaceReports_SeparatorOnly(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, `{"Action":"run","Package":"example","Test":"TestFoo"}
+{"Action":"output","Package":"example","Test":"TestFoo","Output":"==================\n"}
+{"Action":"output","Package":"example","Test":"TestFoo","Output":"just a log\n"}
+{"Action":"output","Package":"example","Test":"TestFoo","Output":"==================\n"}
+{"Action":"pass","Package":"example","Test":"TestFoo","Elapsed":0}
+{"Action":"run","Package":"example","Test":"TestBar"}
+{"Action":"output","Package":"example","Test":"TestBar","Output":"==================\n"}
+{"Action":"output","Package":"example","Test":"TestBar","Output":"WARNING: DATA RACE\n"}
+{"Action":"fail","Package":"example","Elapsed":0}`)
+
+	for _, test := range tm.GetTests {
+		assert.Empty(t, test.Races)
+	}
+
+	messages := func(test string) []string {
+		var logs []string
+		for _, log := range tm.GetLogs(TestReference{Package: "example", Test: test}) {
+			logs = append(logs, log.Message)
+		}
+		return logs
+	}
+
+	// the separators which weren't the start of a report are logged, including
+	// one written as the last line of the test
+	assert.Equal(t, []string{"==================", "just a log", "=================="}, messages("TestFoo"))
+
+	// a report which was never finished is logged when the package ends
+	assert.Equal(t, []string{"==================", "WARNING: DATA RACE"}, messages("TestBar"))
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"slices"
+	"time"
+)
+
+// RunningElapsed is how long the test has been running at 'now' since it last
+// started or resumed, measured from the time of the event so it isn't
+// affected by delays in reading the output. time spent paused by t.Parallel()
+// isn't counted, as parallel tests are paused until the serial tests finish
+func (tn *TestNode) RunningElapsed(now time.Time) time.Duration {
+	start := tn.runningSince()
+	if start.IsZero() {
+		return 0
+	}
+
+	return max(0, now.Sub(start))
+}
+
+// runningSince is the start of the span the test is running in
+func (tn *TestNode) runningSince() time.Time {
+	if len(tn.Spans) > 0 {
+		if last := tn.Spans[len(tn.Spans)-1]; last.End.IsZero() {
+			return last.Start
+		}
+	}
+
+	return tn.StartTime
+}
+
+// GetRunningTests finds the tests which are running, the longest running
+// first. tests whose subtests are running are left out, as they are only
+// waiting on their subtests
+func (tm *TestManager) GetRunningTests() []*TestNode {
+	tm.testLock.RLock()
+	defer tm.testLock.RUnlock()
+
+	waiting := make(map[TestReference]bool)
+	for _, test := range tm.tests {
+		if test.Status != StatusRunning {
+			continue
+		}
+
+		for ref, ok := test.Ref.Parent(); ok; ref, ok = ref.Parent() {
+			waiting[ref] = true
+		}
+	}
+
+	var running []*TestNode
+	for _, test := range tm.tests {
+		if test.Status == StatusRunning && !waiting[test.Ref] {
+			running = append(running, test)
+		}
+	}
+
+	slices.SortStableFunc(running, func(a, b *TestNode) int {
+		return a.runningSince().Compare(b.runningSince())
+	})
+
+	return running
+}
+
+// GetHungTests finds the running tests which have been running for longer
+// than the threshold at 'now'
+func (tm *TestManager) GetHungTests(threshold time.Duration, now time.Time) []*TestNode {
+	if threshold <= 0 {
+		return nil
+	}
+
+	return slices.DeleteFunc(tm.GetRunningTests(), func(test *TestNode) bool {
+		return test.RunningElapsed(now) < threshold
+	})
+}
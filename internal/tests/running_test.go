@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const runningOutput = `{"Time":"2025-01-01T10:00:00Z","Action":"run","Package":"pkg","Test":"TestA"}
+{"Time":"2025-01-01T10:00:01Z","Action":"run","Package":"pkg","Test":"TestA/one"}
+{"Time":"2025-01-01T10:00:02Z","Action":"run","Package":"pkg","Test":"TestB"}
+{"Time":"2025-01-01T10:00:03Z","Action":"pause","Package":"pkg","Test":"TestB"}
+{"Time":"2025-01-01T10:00:04Z","Action":"run","Package":"pkg","Test":"TestC"}
+{"Time":"2025-01-01T10:00:05Z","Action":"pass","Package":"pkg","Test":"TestC"}
+{"Time":"2025-01-01T10:00:06Z","Action":"run","Package":"pkg","Test":"TestD"}`
+
+func TestGetRunningTests(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, runningOutput)
+
+	running := tm.GetRunningTests()
+	require.Len(t, running, 2)
+
+	// TestA is waiting on TestA/one and TestB is paused
+	assert.Equal(t, "TestA/one", running[0].Ref.Test)
+	assert.Equal(t, "TestD", running[1].Ref.Test)
+
+	now := time.Date(2025, 1, 1, 10, 1, 1, 0, time.UTC)
+	assert.Equal(t, time.Minute, running[0].RunningElapsed(now))
+}
+
+func TestGetHungTests(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, runningOutput)
+
+	now := time.Date(2025, 1, 1, 10, 1, 3, 0, time.UTC)
+
+	hung := tm.GetHungTests(time.Minute, now)
+	require.Len(t, hung, 1)
+	assert.Equal(t, "TestA/one", hung[0].Ref.Test)
+
+	assert.Empty(t, tm.GetHungTests(0, now))
+}
+
+func TestRunningElapsed_Paused(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, `{"Time":"2025-01-01T10:00:00Z","Action":"run","Package":"pkg","Test":"TestParallel"}
+{"Time":"2025-01-01T10:00:01Z","Action":"pause","Package":"pkg","Test":"TestParallel"}
+{"Time":"2025-01-01T10:05:00Z","Action":"cont","Package":"pkg","Test":"TestParallel"}`)
+
+	// the time spent waiting on the serial tests isn't counted
+	now := time.Date(2025, 1, 1, 10, 5, 10, 0, time.UTC)
+	assert.Equal(t, 10*time.Second, tm.GetTest(0).RunningElapsed(now))
+	assert.Empty(t, tm.GetHungTests(time.Minute, now))
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/timtatt/sift/pkg/outputparse"
+)
+
+// SkipGroup is the tests which were skipped for the same reason
+type SkipGroup struct {
+	Reason string // empty when no log was written before the test was skipped
+	Tests  []TestReference
+}
+
+// skipReason finds the last log written by the testing package before the
+// test was skipped, which is the message given to t.Skip when it was called
+// with one. The output of t.Log followed by t.SkipNow is identical though, so
+// the reason is only a guess and is shown as the last log. A bare t.Skip logs
+// an empty message, which gives no reason. The lines which continue a
+// multi-line message are indented further than its first line
+func (tm *TestManager) skipReason(testRef TestReference) string {
+	tm.testLogLock.RLock()
+	defer tm.testLogLock.RUnlock()
+
+	logs := tm.testLogs[testRef]
+
+	for i := len(logs) - 1; i >= 0; i-- {
+		if _, _, ok := outputparse.ParseLogLocation(logs[i].Message); !ok {
+			continue
+		}
+
+		indent := logIndent(logs[i].Message)
+		_, reason, _ := strings.Cut(strings.TrimSpace(logs[i].Message), ": ")
+
+		lines := []string{reason}
+		for _, log := range logs[i+1:] {
+			if logIndent(log.Message) <= indent {
+				// the log was followed by other output, so the test was
+				// skipped without a message
+				return ""
+			}
+			lines = append(lines, strings.TrimSpace(log.Message))
+		}
+
+		return strings.Join(lines, " ")
+	}
+
+	return ""
+}
+
+func logIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// GetSkipGroups groups the skipped tests of every package by their reason, the
+// most common reason first. Tests skipped without a reason are always last
+func (tm *TestManager) GetSkipGroups() []SkipGroup {
+	tm.testLock.RLock()
+	defer tm.testLock.RUnlock()
+
+	var groups []SkipGroup
+	for _, test := range tm.tests {
+		if test.Status != StatusSkipped {
+			continue
+		}
+
+		idx := slices.IndexFunc(groups, func(group SkipGroup) bool {
+			return group.Reason == test.SkipReason
+		})
+		if idx < 0 {
+			groups = append(groups, SkipGroup{Reason: test.SkipReason})
+			idx = len(groups) - 1
+		}
+
+		groups[idx].Tests = append(groups[idx].Tests, test.Ref)
+	}
+
+	slices.SortStableFunc(groups, func(a, b SkipGroup) int {
+		if (a.Reason == "") != (b.Reason == "") {
+			return cmp.Compare(b.Reason, a.Reason)
+		}
+
+		if c := cmp.Compare(len(b.Tests), len(a.Tests)); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Reason, b.Reason)
+	})
+
+	return groups
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const skipOutput = `{"Action":"start","Package":"skip"}
+{"Action":"run","Package":"skip","Test":"TestA"}
+{"Action":"output","Package":"skip","Test":"TestA","Output":"    s_test.go:5: setting up\n"}
+{"Action":"output","Package":"skip","Test":"TestA","Output":"    s_test.go:5: needs docker\n"}
+{"Action":"output","Package":"skip","Test":"TestA","Output":"--- SKIP: TestA (0.00s)\n","OutputType":"frame"}
+{"Action":"skip","Package":"skip","Test":"TestA","Elapsed":0}
+{"Action":"run","Package":"skip","Test":"TestB"}
+{"Action":"output","Package":"skip","Test":"TestB","Output":"    s_test.go:6: needs docker\n"}
+{"Action":"skip","Package":"skip","Test":"TestB","Elapsed":0}
+{"Action":"run","Package":"skip","Test":"TestC"}
+{"Action":"output","Package":"skip","Test":"TestC","Output":"--- SKIP: TestC (0.00s)\n","OutputType":"frame"}
+{"Action":"skip","Package":"skip","Test":"TestC","Elapsed":0}
+{"Action":"run","Package":"skip","Test":"TestD"}
+{"Action":"run","Package":"skip","Test":"TestD/sub"}
+{"Action":"output","Package":"skip","Test":"TestD/sub","Output":"    s_test.go:9: flaky\n"}
+{"Action":"output","Package":"skip","Test":"TestD/sub","Output":"        on CI\n"}
+{"Action":"skip","Package":"skip","Test":"TestD/sub","Elapsed":0}
+{"Action":"pass","Package":"skip","Test":"TestD","Elapsed":0}
+{"Action":"run","Package":"skip","Test":"TestE"}
+{"Action":"output","Package":"skip","Test":"TestE","Output":"    s_test.go:12: connecting\n"}
+{"Action":"output","Package":"skip","Test":"TestE","Output":"connection refused\n"}
+{"Action":"skip","Package":"skip","Test":"TestE","Elapsed":0}
+{"Action":"run","Package":"skip","Test":"TestF"}
+{"Action":"output","Package":"skip","Test":"TestF","Output":"    s_test.go:20: connected\n"}
+{"Action":"output","Package":"skip","Test":"TestF","Output":"    s_test.go:21: \n"}
+{"Action":"output","Package":"skip","Test":"TestF","Output":"--- SKIP: TestF (0.00s)\n","OutputType":"frame"}
+{"Action":"skip","Package":"skip","Test":"TestF","Elapsed":0}
+{"Action":"run","Package":"skip","Test":"TestG"}
+{"Action":"output","Package":"skip","Test":"TestG","Output":"    s_test.go:25: setting up\n"}
+{"Action":"output","Package":"skip","Test":"TestG","Output":"--- SKIP: TestG (0.00s)\n","OutputType":"frame"}
+{"Action":"skip","Package":"skip","Test":"TestG","Elapsed":0}
+{"Action":"pass","Package":"skip","Elapsed":0.002}`
+
+func TestSkipReason(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, skipOutput)
+
+	reasons := make(map[string]string)
+	for _, test := range tm.GetTests {
+		reasons[test.Ref.Test] = test.SkipReason
+	}
+
+	assert.Equal(t, map[string]string{
+		"TestA":     "needs docker",
+		"TestB":     "needs docker",
+		"TestC":     "",
+		"TestD":     "",
+		"TestD/sub": "flaky on CI",
+		"TestE":     "",
+		// a bare t.Skip logs an empty message
+		"TestF": "",
+		// t.Log followed by t.SkipNow can't be told apart from t.Skip
+		"TestG": "setting up",
+	}, reasons)
+}
+
+func TestGetSkipGroups(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, skipOutput)
+
+	groups := tm.GetSkipGroups()
+	require.Len(t, groups, 4)
+
+	assert.Equal(t, SkipGroup{
+		Reason: "needs docker",
+		Tests: []TestReference{
+			{Package: "skip", Test: "TestA"},
+			{Package: "skip", Test: "TestB"},
+		},
+	}, groups[0])
+
+	assert.Equal(t, "flaky on CI", groups[1].Reason)
+	assert.Equal(t, "setting up", groups[2].Reason)
+
+	assert.Equal(t, SkipGroup{
+		Tests: []TestReference{
+			{Package: "skip", Test: "TestC"},
+			{Package: "skip", Test: "TestE"},
+			{Package: "skip", Test: "TestF"},
+		},
+	}, groups[3])
+}
@@ -0,0 +1,109 @@
+package tests
+
+type TestStatus int
+
+const (
+	StatusQueued TestStatus = iota
+	StatusRunning
+	StatusPaused // waiting on t.Parallel()
+	StatusPassed
+	StatusFailed
+	StatusSkipped
+	StatusBuildError
+	StatusAborted  // still running when the package exited, eg. after another test panicked
+	StatusTimedOut // still running when the test binary exceeded -timeout
+	StatusVetError // the build failed because of the checks go test runs with vet
+)
+
+func (s TestStatus) String() string {
+	switch s {
+	case StatusQueued:
+		return "queued"
+	case StatusRunning:
+		return "running"
+	case StatusPaused:
+		return "paused"
+	case StatusPassed:
+		return "passed"
+	case StatusFailed:
+		return "failed"
+	case StatusSkipped:
+		return "skipped"
+	case StatusBuildError:
+		return "build error"
+	case StatusAborted:
+		return "aborted"
+	case StatusTimedOut:
+		return "timed out"
+	case StatusVetError:
+		return "vet error"
+	default:
+		return "unknown"
+	}
+}
+
+// Done reports whether the status is final and will no longer change
+func (s TestStatus) Done() bool {
+	switch s {
+	case StatusPassed, StatusFailed, StatusSkipped, StatusBuildError, StatusAborted, StatusTimedOut, StatusVetError:
+		return true
+	default:
+		return false
+	}
+}
+
+// Failure reports whether the test or the build of its package failed
+func (s TestStatus) Failure() bool {
+	return s == StatusFailed || s.BuildFailure()
+}
+
+// BuildFailure reports whether the package failed to build, either from the
+// compiler or from vet
+func (s TestStatus) BuildFailure() bool {
+	return s == StatusBuildError || s == StatusVetError
+}
+
+// Active reports whether the test has started but not yet finished
+func (s TestStatus) Active() bool {
+	return s == StatusRunning || s == StatusPaused
+}
+
+// Transition returns the status after receiving an action from `go test -json`,
+// or "abort" and "timeout" when the package exited before the test finished.
+// Actions which aren't valid from the current status leave it unchanged
+func (s TestStatus) Transition(action string) TestStatus {
+	if s.Done() {
+		return s
+	}
+
+	switch action {
+	case "start", "run":
+		if s == StatusQueued {
+			return StatusRunning
+		}
+	case "pause":
+		if s == StatusRunning {
+			return StatusPaused
+		}
+	case "cont":
+		if s == StatusPaused {
+			return StatusRunning
+		}
+	case "pass":
+		return StatusPassed
+	case "fail":
+		return StatusFailed
+	case "skip":
+		return StatusSkipped
+	case "build-fail":
+		return StatusBuildError
+	case "vet-fail":
+		return StatusVetError
+	case "abort":
+		return StatusAborted
+	case "timeout":
+		return StatusTimedOut
+	}
+
+	return s
+}
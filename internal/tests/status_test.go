@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusTransition(t *testing.T) {
+	tests := []struct {
+		name   string
+		status TestStatus
+		action string
+		want   TestStatus
+	}{
+		{name: "queued to running", status: StatusQueued, action: "run", want: StatusRunning},
+		{name: "running to paused", status: StatusRunning, action: "pause", want: StatusPaused},
+		{name: "paused to running", status: StatusPaused, action: "cont", want: StatusRunning},
+		{name: "running to passed", status: StatusRunning, action: "pass", want: StatusPassed},
+		{name: "running to failed", status: StatusRunning, action: "fail", want: StatusFailed},
+		{name: "running to skipped", status: StatusRunning, action: "skip", want: StatusSkipped},
+		{name: "paused to failed", status: StatusPaused, action: "fail", want: StatusFailed},
+		{name: "queued to build error", status: StatusQueued, action: "build-fail", want: StatusBuildError},
+		{name: "queued to vet error", status: StatusQueued, action: "vet-fail", want: StatusVetError},
+		{name: "paused to aborted", status: StatusPaused, action: "abort", want: StatusAborted},
+		{name: "running to timed out", status: StatusRunning, action: "timeout", want: StatusTimedOut},
+		{name: "failed is not aborted", status: StatusFailed, action: "abort", want: StatusFailed},
+		{name: "cont ignored while running", status: StatusRunning, action: "cont", want: StatusRunning},
+		{name: "pause ignored while queued", status: StatusQueued, action: "pause", want: StatusQueued},
+		{name: "passed is final", status: StatusPassed, action: "fail", want: StatusPassed},
+		{name: "build error is final", status: StatusBuildError, action: "pass", want: StatusBuildError},
+		{name: "unknown action", status: StatusRunning, action: "output", want: StatusRunning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.status.Transition(tt.action))
+		})
+	}
+}
+
+func TestStatusDone(t *testing.T) {
+	assert.False(t, StatusQueued.Done())
+	assert.False(t, StatusRunning.Done())
+	assert.False(t, StatusPaused.Done())
+	assert.True(t, StatusPassed.Done())
+	assert.True(t, StatusFailed.Done())
+	assert.True(t, StatusSkipped.Done())
+	assert.True(t, StatusBuildError.Done())
+	assert.True(t, StatusAborted.Done())
+	assert.True(t, StatusTimedOut.Done())
+	assert.True(t, StatusVetError.Done())
+}
+
+func TestStatusFailure(t *testing.T) {
+	assert.True(t, StatusFailed.Failure())
+	assert.True(t, StatusBuildError.Failure())
+	assert.True(t, StatusVetError.Failure())
+	assert.False(t, StatusPassed.Failure())
+	assert.False(t, StatusSkipped.Failure())
+	assert.False(t, StatusRunning.Failure())
+}
+
+func TestStatusBuildFailure(t *testing.T) {
+	assert.True(t, StatusBuildError.BuildFailure())
+	assert.True(t, StatusVetError.BuildFailure())
+	assert.False(t, StatusFailed.BuildFailure())
+}
@@ -0,0 +1,64 @@
+package tests
+
+// SubtestCount is how many of the subtests under a test failed. Only the
+// subtests without subtests of their own are counted, as a failure is
+// otherwise counted at every level of the tree
+type SubtestCount struct {
+	Total  int
+	Failed int
+}
+
+// GetSubtestCounts counts the subtests under each test which has subtests
+func (tm *TestManager) GetSubtestCounts() map[TestReference]SubtestCount {
+	tm.testLock.RLock()
+	defer tm.testLock.RUnlock()
+
+	parents := make(map[TestReference]bool)
+	for _, test := range tm.tests {
+		for ref, ok := test.Ref.Parent(); ok; ref, ok = ref.Parent() {
+			parents[ref] = true
+		}
+	}
+
+	counts := make(map[TestReference]SubtestCount)
+	for _, test := range tm.tests {
+		if parents[test.Ref] {
+			continue
+		}
+
+		for ref, ok := test.Ref.Parent(); ok; ref, ok = ref.Parent() {
+			count := counts[ref]
+			count.Total++
+			if test.Status.Failure() {
+				count.Failed++
+			}
+			counts[ref] = count
+		}
+	}
+
+	return counts
+}
+
+// FailedItself checks if the test failed for a reason of its own, rather than
+// only because one of its subtests failed. go marks every ancestor of a failed
+// subtest as failed too
+func (tm *TestManager) FailedItself(test *TestNode, subtests SubtestCount) bool {
+	if !test.Status.Failure() {
+		return false
+	}
+
+	if subtests.Failed == 0 || test.Panic != nil || len(test.Races) > 0 {
+		return true
+	}
+
+	tm.testLogLock.RLock()
+	defer tm.testLogLock.RUnlock()
+
+	for _, log := range tm.testLogs[test.Ref] {
+		if log.IsError() {
+			return true
+		}
+	}
+
+	return false
+}
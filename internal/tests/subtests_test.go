@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const subtestOutput = `{"Action":"run","Package":"pkg","Test":"TestA"}
+{"Action":"run","Package":"pkg","Test":"TestA/one"}
+{"Action":"run","Package":"pkg","Test":"TestA/one/x"}
+{"Action":"fail","Package":"pkg","Test":"TestA/one/x"}
+{"Action":"run","Package":"pkg","Test":"TestA/one/y"}
+{"Action":"pass","Package":"pkg","Test":"TestA/one/y"}
+{"Action":"fail","Package":"pkg","Test":"TestA/one"}
+{"Action":"run","Package":"pkg","Test":"TestA/two"}
+{"Action":"pass","Package":"pkg","Test":"TestA/two"}
+{"Action":"fail","Package":"pkg","Test":"TestA"}
+{"Action":"run","Package":"pkg","Test":"TestB"}
+{"Action":"run","Package":"pkg","Test":"TestB/one"}
+{"Action":"fail","Package":"pkg","Test":"TestB/one"}
+{"Action":"output","Package":"pkg","Test":"TestB","Output":"    foo_test.go:20: setup failed\n","OutputType":"error"}
+{"Action":"fail","Package":"pkg","Test":"TestB"}
+{"Action":"run","Package":"pkg","Test":"TestC"}
+{"Action":"fail","Package":"pkg","Test":"TestC"}`
+
+func TestGetSubtestCounts(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, subtestOutput)
+
+	assert.Equal(t, map[TestReference]SubtestCount{
+		{Package: "pkg", Test: "TestA"}:     {Total: 3, Failed: 1},
+		{Package: "pkg", Test: "TestA/one"}: {Total: 2, Failed: 1},
+		{Package: "pkg", Test: "TestB"}:     {Total: 1, Failed: 1},
+	}, tm.GetSubtestCounts())
+}
+
+func TestFailedItself(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	addOutput(t, tm, subtestOutput)
+
+	counts := tm.GetSubtestCounts()
+
+	failedItself := make(map[string]bool)
+	for _, test := range tm.GetTests {
+		failedItself[test.Ref.Test] = tm.FailedItself(test, counts[test.Ref])
+	}
+
+	assert.Equal(t, map[string]bool{
+		"TestA":       false,
+		"TestA/one":   false,
+		"TestA/one/x": true,
+		"TestA/one/y": false,
+		"TestA/two":   false,
+		"TestB":       true, // logged an error of its own
+		"TestB/one":   true,
+		"TestC":       true,
+	}, failedItself)
+}
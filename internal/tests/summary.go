@@ -1,10 +1,16 @@
 package tests
 
 type TestSummary struct {
-	Passed  int
-	Failed  int
-	Skipped int
-	Running int
+	Passed      int
+	Failed      int
+	Skipped     int
+	Aborted     int
+	TimedOut    int
+	Raced       int
+	Running     int
+	Queued      int
+	BuildFailed int // packages which failed to build, as they have no tests
+	VetFailed   int // packages which failed the checks go test runs with vet
 }
 
 type Summary struct {
@@ -18,44 +24,81 @@ func NewSummary() *Summary {
 	}
 }
 
-func (s *Summary) AddToPackage(pkg string, status string) {
+// AddTest counts the test against its package. tests which failed because of
+// a data race are counted as raced rather than failed
+func (s *Summary) AddTest(test *TestNode) {
+	if test.Status == StatusFailed && len(test.Races) > 0 {
+		pkgSummary := s.packages[test.Ref.Package]
+		pkgSummary.Raced++
+		s.packages[test.Ref.Package] = pkgSummary
+		s.testTotal.Raced++
+		return
+	}
+
+	s.AddToPackage(test.Ref.Package, test.Status)
+}
+
+func (s *Summary) AddToPackage(pkg string, status TestStatus) {
 	pkgSummary, ok := s.packages[pkg]
 	if !ok {
 		pkgSummary = TestSummary{}
 	}
 
 	switch status {
-	case "error":
-		// only increment the failed pkgs count
+	case StatusBuildError:
 		// don't increment the total failed tests count
-		pkgSummary.Failed++
-	case "pass":
+		s.testTotal.BuildFailed++
+		pkgSummary.BuildFailed++
+	case StatusVetError:
+		s.testTotal.VetFailed++
+		pkgSummary.VetFailed++
+	case StatusPassed:
 		s.testTotal.Passed++
 		pkgSummary.Passed++
-	case "fail":
+	case StatusFailed:
 		s.testTotal.Failed++
 		pkgSummary.Failed++
-	case "skip":
+	case StatusSkipped:
 		s.testTotal.Skipped++
 		pkgSummary.Skipped++
-	case "run":
+	case StatusAborted:
+		s.testTotal.Aborted++
+		pkgSummary.Aborted++
+	case StatusTimedOut:
+		s.testTotal.TimedOut++
+		pkgSummary.TimedOut++
+	case StatusRunning, StatusPaused:
 		s.testTotal.Running++
 		pkgSummary.Running++
+	case StatusQueued:
+		s.testTotal.Queued++
+		pkgSummary.Queued++
 	}
 
 	s.packages[pkg] = pkgSummary
 }
 
+// Failing checks if any test failed or didn't finish
+func (ts TestSummary) Failing() bool {
+	return ts.Failed > 0 || ts.BuildFailed > 0 || ts.VetFailed > 0 || ts.Aborted > 0 || ts.TimedOut > 0 || ts.Raced > 0
+}
+
 func (s *Summary) Total() TestSummary {
 	return s.testTotal
 }
 
+// PackageSummary counts the packages by their outcome. packages which failed
+// to build or failed vet are counted separately from those with failed tests
 func (s *Summary) PackageSummary() TestSummary {
 	ps := TestSummary{}
 	for _, p := range s.packages {
-		if p.Running > 0 {
+		if p.Running > 0 || p.Queued > 0 {
 			ps.Running++
-		} else if p.Failed > 0 {
+		} else if p.VetFailed > 0 {
+			ps.VetFailed++
+		} else if p.BuildFailed > 0 {
+			ps.BuildFailed++
+		} else if p.Failing() {
 			ps.Failed++
 		} else {
 			ps.Passed++
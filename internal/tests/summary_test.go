@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/timtatt/sift/pkg/outputparse"
 )
 
 func TestNewSummary(t *testing.T) {
@@ -19,35 +20,35 @@ func TestNewSummary(t *testing.T) {
 func TestAddPackage(t *testing.T) {
 	tests := []struct {
 		name     string
-		status   string
+		status   TestStatus
 		wantPass int
 		wantFail int
 		wantRun  int
 	}{
 		{
 			name:     "pass",
-			status:   "pass",
+			status:   StatusPassed,
 			wantPass: 1,
 			wantFail: 0,
 			wantRun:  0,
 		},
 		{
 			name:     "fail",
-			status:   "fail",
+			status:   StatusFailed,
 			wantPass: 0,
 			wantFail: 1,
 			wantRun:  0,
 		},
 		{
 			name:     "run",
-			status:   "run",
+			status:   StatusRunning,
 			wantPass: 0,
 			wantFail: 0,
 			wantRun:  1,
 		},
 		{
 			name:     "unknown",
-			status:   "unknown",
+			status:   TestStatus(-1),
 			wantPass: 0,
 			wantFail: 0,
 			wantRun:  0,
@@ -68,10 +69,10 @@ func TestAddPackage(t *testing.T) {
 
 	t.Run("multiple statuses", func(t *testing.T) {
 		s := NewSummary()
-		s.AddToPackage("pkg1", "pass")
-		s.AddToPackage("pkg2", "fail")
-		s.AddToPackage("pkg3", "run")
-		s.AddToPackage("pkg4", "pass")
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg2", StatusFailed)
+		s.AddToPackage("pkg3", StatusRunning)
+		s.AddToPackage("pkg4", StatusPassed)
 
 		total := s.Total()
 		assert.Equal(t, 2, total.Passed)
@@ -81,9 +82,9 @@ func TestAddPackage(t *testing.T) {
 
 	t.Run("same package multiple times", func(t *testing.T) {
 		s := NewSummary()
-		s.AddToPackage("pkg1", "pass")
-		s.AddToPackage("pkg1", "pass")
-		s.AddToPackage("pkg1", "fail")
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg1", StatusFailed)
 
 		total := s.Total()
 		assert.Equal(t, 2, total.Passed)
@@ -94,10 +95,10 @@ func TestAddPackage(t *testing.T) {
 func TestPackageSummary(t *testing.T) {
 	t.Run("aggregates package data", func(t *testing.T) {
 		s := NewSummary()
-		s.AddToPackage("pkg1", "pass")
-		s.AddToPackage("pkg1", "pass")
-		s.AddToPackage("pkg2", "fail")
-		s.AddToPackage("pkg3", "run")
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg2", StatusFailed)
+		s.AddToPackage("pkg3", StatusRunning)
 
 		pkgSummary := s.PackageSummary()
 		assert.Equal(t, 1, pkgSummary.Passed)
@@ -105,6 +106,42 @@ func TestPackageSummary(t *testing.T) {
 		assert.Equal(t, 1, pkgSummary.Running)
 	})
 
+	t.Run("aborted and timed out packages fail", func(t *testing.T) {
+		s := NewSummary()
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg1", StatusAborted)
+		s.AddToPackage("pkg2", StatusTimedOut)
+
+		total := s.Total()
+		assert.Equal(t, 1, total.Aborted)
+		assert.Equal(t, 1, total.TimedOut)
+		assert.Equal(t, 0, total.Failed)
+
+		pkgSummary := s.PackageSummary()
+		assert.Equal(t, 0, pkgSummary.Passed)
+		assert.Equal(t, 2, pkgSummary.Failed)
+	})
+
+	t.Run("raced tests are counted separately", func(t *testing.T) {
+		s := NewSummary()
+		s.AddTest(&TestNode{
+			Ref:    TestReference{Package: "pkg1", Test: "TestRace"},
+			Status: StatusFailed,
+			Races:  []outputparse.Race{{}},
+		})
+		s.AddTest(&TestNode{
+			Ref:    TestReference{Package: "pkg1", Test: "TestFail"},
+			Status: StatusFailed,
+		})
+
+		total := s.Total()
+		assert.Equal(t, 1, total.Raced)
+		assert.Equal(t, 1, total.Failed)
+
+		pkgSummary := s.PackageSummary()
+		assert.Equal(t, 1, pkgSummary.Failed)
+	})
+
 	t.Run("empty summary", func(t *testing.T) {
 		s := NewSummary()
 		pkgSummary := s.PackageSummary()
@@ -116,10 +153,10 @@ func TestPackageSummary(t *testing.T) {
 
 	t.Run("matches total", func(t *testing.T) {
 		s := NewSummary()
-		s.AddToPackage("pkg1", "pass")
-		s.AddToPackage("pkg2", "pass")
-		s.AddToPackage("pkg3", "fail")
-		s.AddToPackage("pkg4", "run")
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg2", StatusPassed)
+		s.AddToPackage("pkg3", StatusFailed)
+		s.AddToPackage("pkg4", StatusRunning)
 
 		total := s.Total()
 		pkgSummary := s.PackageSummary()
@@ -133,13 +170,13 @@ func TestPackageSummary(t *testing.T) {
 func TestSummary_ComplexScenarios(t *testing.T) {
 	t.Run("lifecycle progression", func(t *testing.T) {
 		s := NewSummary()
-		s.AddToPackage("pkg1", "run")
-		s.AddToPackage("pkg1", "pass")
-		s.AddToPackage("pkg2", "run")
-		s.AddToPackage("pkg2", "fail")
-		s.AddToPackage("pkg3", "run")
-		s.AddToPackage("pkg3", "pass")
-		s.AddToPackage("pkg4", "run")
+		s.AddToPackage("pkg1", StatusRunning)
+		s.AddToPackage("pkg1", StatusPassed)
+		s.AddToPackage("pkg2", StatusRunning)
+		s.AddToPackage("pkg2", StatusFailed)
+		s.AddToPackage("pkg3", StatusRunning)
+		s.AddToPackage("pkg3", StatusPassed)
+		s.AddToPackage("pkg4", StatusRunning)
 
 		total := s.Total()
 		assert.Equal(t, 2, total.Passed)
@@ -151,14 +188,14 @@ func TestSummary_ComplexScenarios(t *testing.T) {
 		s := NewSummary()
 		packages := []struct {
 			name   string
-			status string
+			status TestStatus
 		}{
-			{name: "pkg1", status: "pass"},
-			{name: "pkg2", status: "pass"},
-			{name: "pkg3", status: "pass"},
-			{name: "pkg4", status: "fail"},
-			{name: "pkg5", status: "fail"},
-			{name: "pkg6", status: "run"},
+			{name: "pkg1", status: StatusPassed},
+			{name: "pkg2", status: StatusPassed},
+			{name: "pkg3", status: StatusPassed},
+			{name: "pkg4", status: StatusFailed},
+			{name: "pkg5", status: StatusFailed},
+			{name: "pkg6", status: StatusRunning},
 		}
 
 		for _, p := range packages {
@@ -174,3 +211,33 @@ func TestSummary_ComplexScenarios(t *testing.T) {
 		assert.Equal(t, total, pkgSummary)
 	})
 }
+
+func TestSummary_BuildFailed(t *testing.T) {
+	s := NewSummary()
+	s.AddToPackage("pkg1", StatusBuildError)
+	s.AddToPackage("pkg2", StatusPassed)
+
+	total := s.Total()
+	assert.Equal(t, 1, total.BuildFailed)
+	assert.Equal(t, 0, total.Failed)
+	assert.True(t, total.Failing())
+	assert.Equal(t, 1, s.PackageSummary().BuildFailed)
+	assert.Equal(t, 0, s.PackageSummary().Failed)
+}
+
+func TestSummary_VetFailed(t *testing.T) {
+	s := NewSummary()
+	s.AddToPackage("pkg1", StatusVetError)
+	s.AddToPackage("pkg2", StatusBuildError)
+	s.AddToPackage("pkg3", StatusFailed)
+
+	total := s.Total()
+	assert.Equal(t, 1, total.VetFailed)
+	assert.Equal(t, 1, total.BuildFailed)
+	assert.True(t, total.Failing())
+
+	pkgSummary := s.PackageSummary()
+	assert.Equal(t, 1, pkgSummary.VetFailed)
+	assert.Equal(t, 1, pkgSummary.BuildFailed)
+	assert.Equal(t, 1, pkgSummary.Failed)
+}
@@ -9,15 +9,32 @@ import (
 	"time"
 
 	"github.com/timtatt/sift/pkg/logparse"
+	"github.com/timtatt/sift/pkg/outputparse"
 )
 
 type TestManager struct {
 	tests    []*TestNode
+	packages map[string]*PackageNode
 	testLock sync.RWMutex
 
 	testLogs    map[TestReference][]logparse.LogEntry
 	testLogLock sync.RWMutex
 
+	// output which hasn't been terminated by a newline yet
+	partialOutput map[TestReference]string
+
+	// output of tests which have panicked, until the test or package exits
+	panicOutput map[TestReference][]string
+
+	// output of data race reports which haven't been terminated yet
+	raceOutput map[TestReference][]string
+
+	// packages whose build output came from vet, until the build fails
+	vetFailures map[TestReference]bool
+
+	benchmarks    map[TestReference][]outputparse.Benchmark
+	benchmarkLock sync.RWMutex
+
 	opts TestManagerOpts
 }
 
@@ -29,7 +46,14 @@ func NewTestManager(opts TestManagerOpts) *TestManager {
 	return &TestManager{
 		opts:     opts,
 		tests:    make([]*TestNode, 0),
+		packages: make(map[string]*PackageNode),
 		testLogs: make(map[TestReference][]logparse.LogEntry),
+
+		partialOutput: make(map[TestReference]string),
+		panicOutput:   make(map[TestReference][]string),
+		raceOutput:    make(map[TestReference][]string),
+		vetFailures:   make(map[TestReference]bool),
+		benchmarks:    make(map[TestReference][]outputparse.Benchmark),
 	}
 }
 
@@ -38,10 +62,97 @@ type TestReference struct {
 	Test    string
 }
 
+// IsExample checks if the test is an Example function, which is checked by
+// comparing its output rather than failing itself
+func (ref TestReference) IsExample() bool {
+	return strings.HasPrefix(ref.Test, "Example")
+}
+
+// Parent finds the test which ran the subtest
+func (ref TestReference) Parent() (TestReference, bool) {
+	idx := strings.LastIndex(ref.Test, "/")
+	if idx < 0 {
+		return TestReference{}, false
+	}
+
+	return TestReference{Package: ref.Package, Test: ref.Test[:idx]}, true
+}
+
+// Contains checks if the test is the same test as other, or one of its subtests
+func (ref TestReference) Contains(other TestReference) bool {
+	return ref.Package == other.Package && (ref.Test == other.Test || strings.HasPrefix(other.Test, ref.Test+"/"))
+}
+
 type TestNode struct {
 	Ref     TestReference
 	Elapsed time.Duration
-	Status  string // pass, fail, run
+	Status  TestStatus
+
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Attrs are set by the test with t.Attr(key, value)
+	Attrs []TestAttr
+
+	// Spans are the periods of wall time the test was actively running.
+	// The gaps between spans are where the test was paused by t.Parallel()
+	Spans []TestSpan
+
+	// Fuzz is set for fuzz targets running with `go test -fuzz`
+	Fuzz *FuzzStatus
+
+	// Panic is set when the test panicked
+	Panic *outputparse.Panic
+
+	// Races are the data races detected while running with -race
+	Races []outputparse.Race
+
+	// Diagnostics are the compiler errors of a package which failed to build
+	Diagnostics []outputparse.Diagnostic
+
+	// SkipReason is the last log before the test was skipped, most likely the
+	// message given to t.Skip, see skipReason
+	SkipReason string
+}
+
+type TestSpan struct {
+	Start time.Time
+	End   time.Time // zero while the test is still running
+}
+
+type TestAttr struct {
+	Key   string
+	Value string
+}
+
+type PackageNode struct {
+	Name    string
+	Elapsed time.Duration
+	Status  TestStatus
+
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// startSpan records that the test started or resumed running at t
+func (tn *TestNode) startSpan(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+
+	tn.Spans = append(tn.Spans, TestSpan{Start: t})
+}
+
+// endSpan records that the test paused or finished at t
+func (tn *TestNode) endSpan(t time.Time) {
+	if t.IsZero() || len(tn.Spans) == 0 {
+		return
+	}
+
+	last := &tn.Spans[len(tn.Spans)-1]
+	if last.End.IsZero() {
+		last.End = t
+	}
 }
 
 func CompareTestNode(a, b *TestNode) int {
@@ -70,6 +181,9 @@ type TestOutputLine struct {
 	Test       string    `json:"Test,omitempty"`
 	Elapsed    float64   `json:"Elapsed,omitempty"`
 	Output     string    `json:"output,omitempty"`
+	OutputType string    `json:"OutputType,omitempty"`
+	Key        string    `json:"Key,omitempty"`
+	Value      string    `json:"Value,omitempty"`
 }
 
 func (tm *TestManager) AddTestOutput(testOutput TestOutputLine) {
@@ -85,87 +199,303 @@ func (tm *TestManager) AddTestOutput(testOutput TestOutputLine) {
 
 	switch testOutput.Action {
 	case "output", "build-output":
-		log := strings.TrimRight(testOutput.Output, "\n")
+		output := tm.joinPartialOutput(testRef, testOutput.Output)
 
-		// don't include the log of a package with build failure
-		if log == fmt.Sprintf("# %s", testRef.Package) {
+		// benchmark results are written in two parts, the name and then the
+		// results once the benchmark has completed
+		if strings.HasPrefix(output, "Benchmark") && !strings.HasSuffix(output, "\n") {
+			tm.partialOutput[testRef] = output
 			return
 		}
 
-		var logEntry logparse.LogEntry
-		if tm.opts.ParseLogs {
-			logEntry = logparse.ParseLog(log)
-		} else {
-			logEntry = logparse.LogEntry{
-				Message: log,
-			}
-		}
+		log := strings.TrimRight(output, "\n")
 
-		// provide a time if one isn't present in the log entry
-		if logEntry.Time.IsZero() {
-			logEntry.Time = time.Now()
+		if benchmark, ok := outputparse.ParseBenchmark(log); ok {
+			tm.addBenchmark(pkg, benchmark)
+			tm.finishBenchmark(testRef, testOutput.Time)
 		}
 
-		if shouldSkipLogLine(log) {
+		// fuzz progress is shown alongside the test instead of in the logs
+		if tm.addFuzzOutput(testRef, log) {
 			return
 		}
 
-		tm.testLogLock.Lock()
-		defer tm.testLogLock.Unlock()
-
-		_, ok := tm.testLogs[testRef]
+		// panics are shown as a stack trace instead of in the logs
+		if tm.addPanicOutput(testRef, log) {
+			return
+		}
 
-		if ok {
-			tm.testLogs[testRef] = append(tm.testLogs[testRef], logEntry)
-		} else {
-			tm.testLogs[testRef] = []logparse.LogEntry{logEntry}
+		// data races are shown as a report instead of in the logs
+		if tm.addRaceOutput(testRef, testOutput, log) {
+			return
 		}
 
+		tm.addLog(testRef, testOutput, log)
+
 	case "build-fail":
 		tm.testLock.Lock()
 		defer tm.testLock.Unlock()
 
+		action := testOutput.Action
+		if tm.vetFailures[testRef] {
+			action = "vet-fail"
+			delete(tm.vetFailures, testRef)
+		}
+
 		newTest := &TestNode{
-			Ref:    testRef,
-			Status: "error",
+			Ref:         testRef,
+			Status:      StatusQueued.Transition(action),
+			Diagnostics: tm.buildDiagnostics(testRef),
 		}
 
+		tm.dropQueuedTests(pkg)
 		tm.tests = slices.Insert(tm.tests, 0, newTest)
 
+		pkgNode := tm.getPackage(pkg)
+		pkgNode.Status = pkgNode.Status.Transition(action)
+
+	case "start":
+		tm.testLock.Lock()
+		defer tm.testLock.Unlock()
+
+		pkgNode := tm.getPackage(pkg)
+		pkgNode.Status = pkgNode.Status.Transition(testOutput.Action)
+		pkgNode.StartTime = testOutput.Time
+
 	case "run":
 		tm.testLock.Lock()
 		defer tm.testLock.Unlock()
 
+		// older versions of go don't send a start action for the package
+		if pkgNode := tm.getPackage(pkg); pkgNode.Status == StatusQueued {
+			pkgNode.Status = pkgNode.Status.Transition("start")
+			pkgNode.StartTime = testOutput.Time
+		}
+
+		if isBenchmark(testRef.Test) {
+			tm.passBenchmarks(pkg, testRef, testOutput.Time)
+		}
+
+		// tests listed with `go test -list` are already in the tree
+		if test := tm.findTest(testRef); test != nil && test.Status == StatusQueued {
+			test.Status = test.Status.Transition(testOutput.Action)
+			test.StartTime = testOutput.Time
+			test.startSpan(testOutput.Time)
+			return
+		}
+
 		newTest := &TestNode{
-			Ref:    testRef,
-			Status: "run",
+			Ref:       testRef,
+			Status:    StatusRunning,
+			StartTime: testOutput.Time,
 		}
+		newTest.startSpan(testOutput.Time)
 
-		insertIdx := len(tm.tests)
-		for i, t := range tm.tests {
-			if CompareTestNode(newTest, t) < 0 {
-				insertIdx = i
-				break
-			}
+		tm.insertTest(newTest)
+	case "attr":
+		tm.testLock.Lock()
+		defer tm.testLock.Unlock()
+
+		if test := tm.findTest(testRef); test != nil {
+			test.Attrs = append(test.Attrs, TestAttr{
+				Key:   testOutput.Key,
+				Value: testOutput.Value,
+			})
 		}
+	case "pause":
+		tm.testLock.Lock()
+		defer tm.testLock.Unlock()
 
-		tm.tests = slices.Insert(tm.tests, insertIdx, newTest)
+		if test := tm.findTest(testRef); test != nil {
+			test.Status = test.Status.Transition(testOutput.Action)
+			test.endSpan(testOutput.Time)
+		}
+	case "cont":
+		tm.testLock.Lock()
+		defer tm.testLock.Unlock()
+
+		if test := tm.findTest(testRef); test != nil {
+			test.Status = test.Status.Transition(testOutput.Action)
+			test.startSpan(testOutput.Time)
+		}
 	case "pass", "fail", "skip":
+		tm.flushRaceOutput(testRef, testOutput.Time)
+
 		tm.testLock.Lock()
 		defer tm.testLock.Unlock()
 
-		testIdx := slices.IndexFunc(tm.tests, func(t *TestNode) bool {
-			return t.Ref == testRef
-		})
-		if testIdx > -1 {
-			test := tm.tests[testIdx]
+		elapsed := time.Duration(float64(time.Second) * testOutput.Elapsed)
+
+		if testRef.Test == "" {
+			pkgNode := tm.getPackage(pkg)
+			pkgNode.Status = pkgNode.Status.Transition(testOutput.Action)
+			pkgNode.Elapsed = elapsed
+			pkgNode.EndTime = testOutput.Time
 
-			if test.Status != "error" {
-				test.Status = testOutput.Action
+			if testOutput.Action == "pass" {
+				tm.passBenchmarks(pkg, TestReference{}, testOutput.Time)
+			} else {
+				tm.abortTests(pkg, testOutput.Time)
 			}
-			test.Elapsed = time.Duration(float64(time.Second) * testOutput.Elapsed)
+
+			tm.dropQueuedTests(pkg)
+		}
+
+		if test := tm.findTest(testRef); test != nil {
+			tm.finishPanic(test)
+
+			test.Status = test.Status.Transition(testOutput.Action)
+			test.Elapsed = elapsed
+			test.EndTime = testOutput.Time
+			test.endSpan(testOutput.Time)
+
+			if test.Status == StatusSkipped {
+				test.SkipReason = tm.skipReason(testRef)
+			}
+		}
+	}
+}
+
+// addLog adds a line of output to the logs of the test
+func (tm *TestManager) addLog(testRef TestReference, testOutput TestOutputLine, log string) {
+	// don't include the log of a package with build failure
+	if log == fmt.Sprintf("# %s", testRef.Package) {
+		return
+	}
+
+	// vet writes its own header, which is how its failures are told apart
+	if isBuildHeader(testOutput, log) {
+		if isVetHeader(log) {
+			tm.vetFailures[testRef] = true
+		}
+		return
+	}
+
+	// the remaining lines of a multi-line t.Error are kept with its first line
+	if testOutput.OutputType == logparse.OutputTypeErrorContinue && tm.continueLog(testRef, log) {
+		return
+	}
+
+	if isDiagnosticContinuation(testOutput, log) && tm.continueLog(testRef, log) {
+		return
+	}
+
+	var logEntry logparse.LogEntry
+	if tm.opts.ParseLogs {
+		logEntry = logparse.ParseLog(log)
+	} else {
+		logEntry = logparse.LogEntry{
+			Message: log,
+		}
+	}
+
+	// provide a time if one isn't present in the log entry
+	if logEntry.Time.IsZero() {
+		logEntry.Time = time.Now()
+	}
+
+	logEntry.OutputType = testOutput.OutputType
+
+	if testOutput.OutputType == logparse.OutputTypeFrame || shouldSkipLogLine(log) {
+		return
+	}
+
+	tm.testLogLock.Lock()
+	defer tm.testLogLock.Unlock()
+
+	_, ok := tm.testLogs[testRef]
+	logEntry.Index = len(tm.testLogs[testRef])
+
+	if ok {
+		tm.testLogs[testRef] = append(tm.testLogs[testRef], logEntry)
+	} else {
+		tm.testLogs[testRef] = []logparse.LogEntry{logEntry}
+	}
+}
+
+// go doesn't send a pass action for benchmarks, so a benchmark is marked as
+// passed once its result is written. benchmarks which only run sub-benchmarks
+// have no result, so they are passed once the next benchmark starts, as
+// benchmarks run one at a time. the benchmarks running the next benchmark are
+// left running. must be called while holding the testLock
+func (tm *TestManager) passBenchmarks(pkg string, next TestReference, t time.Time) {
+	for _, test := range tm.tests {
+		if test.Ref.Package != pkg || !isBenchmark(test.Ref.Test) || test.Ref.Contains(next) {
+			continue
 		}
+
+		tm.passBenchmark(test, t)
+	}
+}
+
+// passBenchmark must be called while holding the testLock
+func (tm *TestManager) passBenchmark(test *TestNode, t time.Time) {
+	if !test.Status.Active() {
+		return
 	}
+
+	test.Status = test.Status.Transition("pass")
+	test.EndTime = t
+	test.endSpan(t)
+
+	if !test.StartTime.IsZero() {
+		test.Elapsed = t.Sub(test.StartTime)
+	}
+}
+
+// continueLog appends the line to the last log of the test, if there is one.
+// the logs are copied rather than changed in place, as GetLogs hands them to
+// the view without a lock
+func (tm *TestManager) continueLog(testRef TestReference, line string) bool {
+	tm.testLogLock.Lock()
+	defer tm.testLogLock.Unlock()
+
+	logs := tm.testLogs[testRef]
+	if len(logs) == 0 {
+		return false
+	}
+
+	logs = slices.Clone(logs)
+	last := &logs[len(logs)-1]
+	last.Continuation = append(slices.Clone(last.Continuation), line)
+
+	tm.testLogs[testRef] = logs
+
+	return true
+}
+
+func (tm *TestManager) joinPartialOutput(testRef TestReference, output string) string {
+	partial, ok := tm.partialOutput[testRef]
+	if !ok {
+		return output
+	}
+
+	delete(tm.partialOutput, testRef)
+
+	return partial + output
+}
+
+// getPackage must be called while holding the testLock
+func (tm *TestManager) getPackage(pkg string) *PackageNode {
+	pkgNode, ok := tm.packages[pkg]
+	if !ok {
+		pkgNode = &PackageNode{Name: pkg}
+		tm.packages[pkg] = pkgNode
+	}
+
+	return pkgNode
+}
+
+// findTest must be called while holding the testLock
+func (tm *TestManager) findTest(testRef TestReference) *TestNode {
+	testIdx := slices.IndexFunc(tm.tests, func(t *TestNode) bool {
+		return t.Ref == testRef
+	})
+	if testIdx == -1 {
+		return nil
+	}
+
+	return tm.tests[testIdx]
 }
 
 func (tm *TestManager) GetTests(yield func(int, *TestNode) bool) {
@@ -191,6 +521,27 @@ func (tm *TestManager) GetTest(index int) *TestNode {
 	return tm.tests[index]
 }
 
+func (tm *TestManager) GetPackage(pkg string) *PackageNode {
+	tm.testLock.RLock()
+	defer tm.testLock.RUnlock()
+
+	return tm.packages[pkg]
+}
+
+// GetPackages copies the packages, so they can be read while iterating over
+// the tests without locking them again
+func (tm *TestManager) GetPackages() map[string]PackageNode {
+	tm.testLock.RLock()
+	defer tm.testLock.RUnlock()
+
+	packages := make(map[string]PackageNode, len(tm.packages))
+	for name, pkgNode := range tm.packages {
+		packages[name] = *pkgNode
+	}
+
+	return packages
+}
+
 func (tm *TestManager) GetTestCount() int {
 	tm.testLock.RLock()
 	defer tm.testLock.RUnlock()
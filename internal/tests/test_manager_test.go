@@ -69,26 +69,31 @@ func TestAddTestOutput(t *testing.T) {
 		tests := []struct {
 			action      string
 			elapsed     float64
+			wantStatus  TestStatus
 			wantElapsed time.Duration
 		}{
 			{
 				action:      "run",
 				elapsed:     0,
+				wantStatus:  StatusRunning,
 				wantElapsed: 0,
 			},
 			{
 				action:      "pass",
 				elapsed:     1.5,
+				wantStatus:  StatusPassed,
 				wantElapsed: time.Duration(1.5 * float64(time.Second)),
 			},
 			{
 				action:      "fail",
 				elapsed:     0.5,
+				wantStatus:  StatusFailed,
 				wantElapsed: time.Duration(0.5 * float64(time.Second)),
 			},
 			{
 				action:      "skip",
 				elapsed:     0.0,
+				wantStatus:  StatusSkipped,
 				wantElapsed: 0,
 			},
 		}
@@ -114,7 +119,7 @@ func TestAddTestOutput(t *testing.T) {
 
 				test := tm.GetTest(0)
 				require.NotNil(t, test)
-				assert.Equal(t, tt.action, test.Status)
+				assert.Equal(t, tt.wantStatus, test.Status)
 				if tt.elapsed > 0 {
 					assert.Equal(t, tt.wantElapsed, test.Elapsed)
 				}
@@ -410,7 +415,7 @@ func TestBuildErrorHandling(t *testing.T) {
 	require.NotNil(t, test)
 	assert.Equal(t, "github.com/example/pkg", test.Ref.Package)
 	assert.Equal(t, "", test.Ref.Test)
-	assert.Equal(t, "error", test.Status)
+	assert.Equal(t, StatusBuildError, test.Status)
 
 	buildErrRef := TestReference{Package: "github.com/example/pkg", Test: ""}
 	assert.Equal(t, 1, tm.GetLogCount(buildErrRef))
@@ -419,3 +424,193 @@ func TestBuildErrorHandling(t *testing.T) {
 	require.Len(t, logs, 1)
 	assert.Equal(t, "pkg/file.go:10:5: undefined: someFunction", logs[0].Message)
 }
+
+func TestTestSpans(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	start := time.Date(2025, 10, 5, 9, 0, 0, 0, time.UTC)
+
+	for _, line := range []TestOutputLine{
+		{Action: "run", Package: "pkg", Test: "TestParallel", Time: start},
+		{Action: "pause", Package: "pkg", Test: "TestParallel", Time: start.Add(time.Second)},
+		{Action: "cont", Package: "pkg", Test: "TestParallel", Time: start.Add(3 * time.Second)},
+		{Action: "pass", Package: "pkg", Test: "TestParallel", Time: start.Add(4 * time.Second)},
+	} {
+		tm.AddTestOutput(line)
+	}
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Equal(t, []TestSpan{
+		{Start: start, End: start.Add(time.Second)},
+		{Start: start.Add(3 * time.Second), End: start.Add(4 * time.Second)},
+	}, test.Spans)
+
+	t.Run("missing time", func(t *testing.T) {
+		tm := NewTestManager(TestManagerOpts{})
+		tm.AddTestOutput(TestOutputLine{Action: "run", Package: "pkg", Test: "Test"})
+		tm.AddTestOutput(TestOutputLine{Action: "pass", Package: "pkg", Test: "Test"})
+
+		test := tm.GetTest(0)
+		require.NotNil(t, test)
+		assert.Empty(t, test.Spans)
+	})
+}
+
+func TestTestLifecycle(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	start := time.Date(2025, 10, 5, 9, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		line       TestOutputLine
+		wantStatus TestStatus
+		wantPkg    TestStatus
+	}{
+		{
+			line:       TestOutputLine{Action: "start", Package: "pkg", Time: start},
+			wantStatus: StatusQueued,
+			wantPkg:    StatusRunning,
+		},
+		{
+			line:       TestOutputLine{Action: "run", Package: "pkg", Test: "Test", Time: start},
+			wantStatus: StatusRunning,
+			wantPkg:    StatusRunning,
+		},
+		{
+			line:       TestOutputLine{Action: "pause", Package: "pkg", Test: "Test", Time: start.Add(time.Second)},
+			wantStatus: StatusPaused,
+			wantPkg:    StatusRunning,
+		},
+		{
+			line:       TestOutputLine{Action: "cont", Package: "pkg", Test: "Test", Time: start.Add(2 * time.Second)},
+			wantStatus: StatusRunning,
+			wantPkg:    StatusRunning,
+		},
+		{
+			line:       TestOutputLine{Action: "fail", Package: "pkg", Test: "Test", Time: start.Add(3 * time.Second)},
+			wantStatus: StatusFailed,
+			wantPkg:    StatusRunning,
+		},
+		{
+			line:       TestOutputLine{Action: "fail", Package: "pkg", Time: start.Add(4 * time.Second)},
+			wantStatus: StatusFailed,
+			wantPkg:    StatusFailed,
+		},
+	}
+
+	for _, step := range steps {
+		tm.AddTestOutput(step.line)
+
+		if test := tm.GetTest(0); test != nil {
+			assert.Equal(t, step.wantStatus, test.Status, step.line.Action)
+		}
+
+		pkg := tm.GetPackage("pkg")
+		require.NotNil(t, pkg)
+		assert.Equal(t, step.wantPkg, pkg.Status, step.line.Action)
+	}
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Equal(t, start, test.StartTime)
+	assert.Equal(t, start.Add(3*time.Second), test.EndTime)
+
+	pkg := tm.GetPackage("pkg")
+	require.NotNil(t, pkg)
+	assert.Equal(t, start, pkg.StartTime)
+	assert.Equal(t, start.Add(4*time.Second), pkg.EndTime)
+
+	// the packages are copied, so they aren't changed by later output
+	packages := tm.GetPackages()
+	assert.Equal(t, *pkg, packages["pkg"])
+
+	tm.AddTestOutput(TestOutputLine{Action: "start", Package: "pkg", Time: start.Add(time.Minute)})
+	assert.Equal(t, start, packages["pkg"].StartTime)
+}
+
+func TestTestAttrs(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+
+	tm.AddTestOutput(TestOutputLine{Action: "run", Package: "pkg", Test: "Test"})
+	tm.AddTestOutput(TestOutputLine{Action: "attr", Package: "pkg", Test: "Test", Key: "owner", Value: "payments"})
+	tm.AddTestOutput(TestOutputLine{Action: "attr", Package: "pkg", Test: "Test", Key: "ticket", Value: "PAY-123"})
+
+	// attributes for unknown tests are ignored
+	tm.AddTestOutput(TestOutputLine{Action: "attr", Package: "pkg", Test: "Unknown", Key: "owner", Value: "search"})
+
+	test := tm.GetTest(0)
+	require.NotNil(t, test)
+	assert.Equal(t, []TestAttr{
+		{Key: "owner", Value: "payments"},
+		{Key: "ticket", Value: "PAY-123"},
+	}, test.Attrs)
+	assert.Equal(t, 1, tm.GetTestCount())
+}
+
+func TestOutputType(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{ParseLogs: true})
+	testRef := TestReference{Package: "pkg", Test: "Test"}
+
+	for _, line := range []TestOutputLine{
+		{Action: "run", Package: "pkg", Test: "Test"},
+		{Action: "output", Package: "pkg", Test: "Test", Output: "=== RUN   Test\n", OutputType: "frame"},
+		{Action: "output", Package: "pkg", Test: "Test", Output: "application log\n"},
+		{Action: "output", Package: "pkg", Test: "Test", Output: "    foo_test.go:12: \n", OutputType: "error"},
+		{Action: "output", Package: "pkg", Test: "Test", Output: "        expected 1\n", OutputType: "error-continue"},
+		{Action: "output", Package: "pkg", Test: "Test", Output: "=== PAUSE Test\n", OutputType: "frame"},
+	} {
+		tm.AddTestOutput(line)
+	}
+
+	logs := tm.GetLogs(testRef)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "", logs[0].OutputType)
+	assert.False(t, logs[0].IsError())
+	assert.Equal(t, "error", logs[1].OutputType)
+	assert.True(t, logs[1].IsError())
+
+	// the continuation is kept with the first line of the error
+	assert.Equal(t, []string{"        expected 1"}, logs[1].Continuation)
+
+	// logs which have already been read aren't changed by later continuations
+	tm.AddTestOutput(TestOutputLine{Action: "output", Package: "pkg", Test: "Test", Output: "        got 2\n", OutputType: "error-continue"})
+	assert.Equal(t, []string{"        expected 1"}, logs[1].Continuation)
+	assert.Equal(t, []string{"        expected 1", "        got 2"}, tm.GetLogs(testRef)[1].Continuation)
+}
+
+func TestOutputType_ContinuationWithoutError(t *testing.T) {
+	tm := NewTestManager(TestManagerOpts{})
+	testRef := TestReference{Package: "pkg", Test: "Test"}
+
+	tm.AddTestOutput(TestOutputLine{Action: "run", Package: "pkg", Test: "Test"})
+	tm.AddTestOutput(TestOutputLine{Action: "output", Package: "pkg", Test: "Test", Output: "        expected 1\n", OutputType: "error-continue"})
+
+	logs := tm.GetLogs(testRef)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "        expected 1", logs[0].Message)
+	assert.True(t, logs[0].IsError())
+}
+
+func TestIsExample(t *testing.T) {
+	assert.True(t, TestReference{Package: "pkg", Test: "ExampleFoo"}.IsExample())
+	assert.True(t, TestReference{Package: "pkg", Test: "Example_suffix"}.IsExample())
+	assert.False(t, TestReference{Package: "pkg", Test: "TestExample"}.IsExample())
+	assert.False(t, TestReference{Package: "pkg"}.IsExample())
+}
+
+func TestTestReferenceParent(t *testing.T) {
+	parent, ok := TestReference{Package: "pkg", Test: "TestA/one/x"}.Parent()
+	assert.True(t, ok)
+	assert.Equal(t, TestReference{Package: "pkg", Test: "TestA/one"}, parent)
+
+	_, ok = TestReference{Package: "pkg", Test: "TestA"}.Parent()
+	assert.False(t, ok)
+}
+
+func TestTestReferenceContains(t *testing.T) {
+	ref := TestReference{Package: "pkg", Test: "TestA"}
+
+	assert.True(t, ref.Contains(ref))
+	assert.True(t, ref.Contains(TestReference{Package: "pkg", Test: "TestA/one"}))
+	assert.False(t, ref.Contains(TestReference{Package: "pkg", Test: "TestAB"}))
+	assert.False(t, ref.Contains(TestReference{Package: "other", Test: "TestA/one"}))
+}
@@ -3,12 +3,16 @@ package main
 import (
 	"github.com/alecthomas/kong"
 	"github.com/timtatt/sift/cmd"
+	"github.com/timtatt/sift/internal/sift"
 )
 
 func main() {
 	var cli cmd.CLI
 
-	ctx := kong.Parse(&cli)
-	err := ctx.Run()
+	ctx := kong.Parse(&cli,
+		kong.Configuration(kong.JSON, cmd.ConfigPaths()...),
+		kong.Vars{"version": sift.Version},
+	)
+	err := ctx.Run(&cli)
 	ctx.FatalIfErrorf(err)
 }
@@ -0,0 +1,87 @@
+package benchdiff
+
+import (
+	"math"
+	"strings"
+)
+
+// Alpha is the significance level, differences with a larger p-value are
+// treated as noise
+const Alpha = 0.05
+
+type Comparison struct {
+	Unit string
+	Old  []float64
+	New  []float64
+
+	OldMean float64
+	NewMean float64
+
+	// Delta is the percentage change from old to new
+	Delta  float64
+	PValue float64
+}
+
+// Compare compares the samples of a single metric between two runs
+func Compare(unit string, old, new []float64) Comparison {
+	_, p := MannWhitneyU(old, new)
+
+	c := Comparison{
+		Unit:    unit,
+		Old:     old,
+		New:     new,
+		OldMean: mean(old),
+		NewMean: mean(new),
+		PValue:  p,
+	}
+
+	if c.OldMean != 0 {
+		c.Delta = (c.NewMean - c.OldMean) / c.OldMean * 100
+	} else if c.NewMean != 0 {
+		c.Delta = math.Inf(1)
+	}
+
+	return c
+}
+
+// Significant checks if the difference is unlikely to be caused by noise
+func (c Comparison) Significant() bool {
+	return c.PValue < Alpha && c.Delta != 0
+}
+
+// Regression checks if the metric significantly changed for the worse
+func (c Comparison) Regression() bool {
+	if !c.Significant() {
+		return false
+	}
+
+	if HigherIsBetter(c.Unit) {
+		return c.Delta < 0
+	}
+
+	return c.Delta > 0
+}
+
+// Improvement checks if the metric significantly changed for the better
+func (c Comparison) Improvement() bool {
+	return c.Significant() && !c.Regression()
+}
+
+// HigherIsBetter checks if a larger value of the unit is an improvement, such
+// as throughput in MB/s. For all other units, such as ns/op, lower is better
+func HigherIsBetter(unit string) bool {
+	return strings.HasSuffix(unit, "/s")
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+
+	return total / float64(len(values))
+}
@@ -0,0 +1,78 @@
+package benchdiff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name            string
+		unit            string
+		old             []float64
+		new             []float64
+		wantDelta       float64
+		wantRegression  bool
+		wantImprovement bool
+	}{
+		{
+			name:           "slower",
+			unit:           "ns/op",
+			old:            []float64{100, 101, 102, 103, 104},
+			new:            []float64{120, 121, 122, 123, 124},
+			wantDelta:      19.6078431372549,
+			wantRegression: true,
+		},
+		{
+			name:            "faster",
+			unit:            "ns/op",
+			old:             []float64{120, 121, 122, 123, 124},
+			new:             []float64{100, 101, 102, 103, 104},
+			wantDelta:       -16.39344262295082,
+			wantImprovement: true,
+		},
+		{
+			name:            "higher throughput",
+			unit:            "MB/s",
+			old:             []float64{100, 101, 102, 103, 104},
+			new:             []float64{120, 121, 122, 123, 124},
+			wantDelta:       19.6078431372549,
+			wantImprovement: true,
+		},
+		{
+			name:      "not enough samples",
+			unit:      "ns/op",
+			old:       []float64{100},
+			new:       []float64{200},
+			wantDelta: 100,
+		},
+		{
+			name:      "noise",
+			unit:      "ns/op",
+			old:       []float64{100, 120, 110},
+			new:       []float64{105, 115, 112},
+			wantDelta: 0.6060606060606061,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Compare(tt.unit, tt.old, tt.new)
+
+			assert.InDelta(t, tt.wantDelta, c.Delta, 1e-9)
+			assert.Equal(t, tt.wantRegression, c.Regression())
+			assert.Equal(t, tt.wantImprovement, c.Improvement())
+		})
+	}
+}
+
+func TestCompare_ZeroOld(t *testing.T) {
+	c := Compare("allocs/op", []float64{0, 0}, []float64{1, 1})
+	assert.True(t, math.IsInf(c.Delta, 1))
+
+	c = Compare("allocs/op", []float64{0, 0}, []float64{0, 0})
+	assert.Equal(t, 0.0, c.Delta)
+	assert.False(t, c.Significant())
+}
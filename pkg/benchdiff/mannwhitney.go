@@ -0,0 +1,155 @@
+package benchdiff
+
+import (
+	"math"
+	"slices"
+)
+
+// the largest sample size where the exact distribution of U is calculated,
+// larger samples use the normal approximation
+const maxExactSampleSize = 12
+
+// MannWhitneyU performs a two-sided Mann-Whitney U test on the samples and
+// returns the U statistic of x and the p-value. The test makes no assumption
+// about the distribution of the samples, which suits benchmark timings
+func MannWhitneyU(x, y []float64) (float64, float64) {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 0, 1
+	}
+
+	ranks, ties := rank(x, y)
+
+	var r1 float64
+	for _, r := range ranks[:n1] {
+		r1 += r
+	}
+
+	u := r1 - float64(n1*(n1+1))/2
+
+	if !ties && n1 <= maxExactSampleSize && n2 <= maxExactSampleSize {
+		return u, exactP(u, n1, n2)
+	}
+
+	return u, normalP(u, n1, n2, ranks)
+}
+
+// rank assigns ranks to the combined samples, with x first followed by y.
+// tied values receive the average of their ranks
+func rank(x, y []float64) ([]float64, bool) {
+	values := slices.Concat(x, y)
+
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		switch {
+		case values[a] < values[b]:
+			return -1
+		case values[a] > values[b]:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	ranks := make([]float64, len(values))
+	ties := false
+
+	for i := 0; i < len(order); {
+		j := i + 1
+		for j < len(order) && values[order[j]] == values[order[i]] {
+			j++
+		}
+
+		if j-i > 1 {
+			ties = true
+		}
+
+		// ranks are 1-based, so the average of i+1..j
+		avg := float64(i+1+j) / 2
+		for k := i; k < j; k++ {
+			ranks[order[k]] = avg
+		}
+
+		i = j
+	}
+
+	return ranks, ties
+}
+
+// exactP calculates the p-value from the exact distribution of U, by counting
+// the arrangements of the samples which result in each value of U
+func exactP(u float64, n1, n2 int) float64 {
+	// counts[i][j][k] is the number of arrangements of i values from x and
+	// j values from y where U = k
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			counts[i][j] = make([]float64, i*j+1)
+
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+
+			for k := range counts[i][j] {
+				// the largest value is from x, and is greater than all j values from y
+				if k-j >= 0 && k-j < len(counts[i-1][j]) {
+					counts[i][j][k] += counts[i-1][j][k-j]
+				}
+				// the largest value is from y
+				if k < len(counts[i][j-1]) {
+					counts[i][j][k] += counts[i][j-1][k]
+				}
+			}
+		}
+	}
+
+	dist := counts[n1][n2]
+
+	var total, lower, upper float64
+	for k, c := range dist {
+		total += c
+		if float64(k) <= u {
+			lower += c
+		}
+		if float64(k) >= u {
+			upper += c
+		}
+	}
+
+	return min(1, 2*min(lower, upper)/total)
+}
+
+// normalP approximates the p-value using the normal distribution, correcting
+// for ties and continuity
+func normalP(u float64, n1, n2 int, ranks []float64) float64 {
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+
+	// count the size of each group of tied ranks
+	tieCounts := make(map[float64]float64)
+	for _, r := range ranks {
+		tieCounts[r]++
+	}
+
+	var tieCorrection float64
+	for _, t := range tieCounts {
+		tieCorrection += t*t*t - t
+	}
+
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		return 1
+	}
+
+	return math.Erfc(z / math.Sqrt2)
+}
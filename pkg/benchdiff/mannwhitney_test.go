@@ -0,0 +1,80 @@
+package benchdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name  string
+		x     []float64
+		y     []float64
+		wantU float64
+		wantP float64
+	}{
+		{
+			name:  "completely separated",
+			x:     []float64{1, 2, 3, 4, 5},
+			y:     []float64{6, 7, 8, 9, 10},
+			wantU: 0,
+			wantP: 2.0 / 252,
+		},
+		{
+			name:  "completely separated reversed",
+			x:     []float64{6, 7, 8, 9, 10},
+			y:     []float64{1, 2, 3, 4, 5},
+			wantU: 25,
+			wantP: 2.0 / 252,
+		},
+		{
+			name:  "interleaved",
+			x:     []float64{1, 3, 5},
+			y:     []float64{2, 4, 6},
+			wantU: 3,
+			wantP: 0.7,
+		},
+		{
+			name:  "single samples",
+			x:     []float64{1},
+			y:     []float64{2},
+			wantU: 0,
+			wantP: 1,
+		},
+		{
+			name:  "empty sample",
+			x:     []float64{},
+			y:     []float64{2},
+			wantU: 0,
+			wantP: 1,
+		},
+		{
+			name:  "identical with ties",
+			x:     []float64{5, 5, 5},
+			y:     []float64{5, 5, 5},
+			wantU: 4.5,
+			wantP: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, p := MannWhitneyU(tt.x, tt.y)
+			assert.Equal(t, tt.wantU, u)
+			assert.InDelta(t, tt.wantP, p, 1e-9)
+		})
+	}
+}
+
+func TestMannWhitneyU_NormalApproximation(t *testing.T) {
+	var x, y []float64
+	for i := range 20 {
+		x = append(x, float64(i))
+		y = append(y, float64(i+20))
+	}
+
+	u, p := MannWhitneyU(x, y)
+	assert.Equal(t, 0.0, u)
+	assert.Less(t, p, 0.0001)
+}
@@ -0,0 +1,56 @@
+package linediff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := map[string]struct {
+		a, b     []string
+		expected []Line
+	}{
+		"equal": {
+			a: []string{"a", "b"},
+			b: []string{"a", "b"},
+			expected: []Line{
+				{Op: Equal, Text: "a"},
+				{Op: Equal, Text: "b"},
+			},
+		},
+		"changed line": {
+			a: []string{"a", "x", "c"},
+			b: []string{"a", "b", "c"},
+			expected: []Line{
+				{Op: Equal, Text: "a"},
+				{Op: Delete, Text: "x"},
+				{Op: Insert, Text: "b"},
+				{Op: Equal, Text: "c"},
+			},
+		},
+		"inserted and deleted": {
+			a: []string{"a", "b", "c"},
+			b: []string{"b", "c", "d"},
+			expected: []Line{
+				{Op: Delete, Text: "a"},
+				{Op: Equal, Text: "b"},
+				{Op: Equal, Text: "c"},
+				{Op: Insert, Text: "d"},
+			},
+		},
+		"empty": {
+			a: nil,
+			b: []string{"a"},
+			expected: []Line{
+				{Op: Insert, Text: "a"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Diff(tc.a, tc.b))
+		})
+	}
+}
@@ -13,6 +13,34 @@ type LogEntry struct {
 	Level      string                   `json:"level"`
 	Message    string                   `json:"msg"`
 	Additional []LogEntryAdditionalProp `json:"-"`
+
+	// OutputType is provided by `go test -json` to mark output produced by the
+	// testing framework rather than the code under test
+	OutputType string `json:"-"`
+
+	// Continuation are the lines following the first line of a multi-line
+	// t.Error / t.Fatal, kept with it so the failure can be parsed as a whole
+	Continuation []string `json:"-"`
+
+	// Index is the position of the entry within the logs of its test, which
+	// tells apart logs written at the same time
+	Index int `json:"-"`
+}
+
+const (
+	OutputTypeError         = "error"          // first line of a t.Error / t.Fatal
+	OutputTypeErrorContinue = "error-continue" // subsequent lines of a t.Error / t.Fatal
+	OutputTypeFrame         = "frame"          // lines such as "=== RUN" and "--- PASS"
+)
+
+// IsError checks if the log was written by a test failure such as t.Error
+func (se LogEntry) IsError() bool {
+	return se.OutputType == OutputTypeError || se.OutputType == OutputTypeErrorContinue
+}
+
+// Lines returns the message along with its continuation lines
+func (se LogEntry) Lines() []string {
+	return append([]string{se.Message}, se.Continuation...)
 }
 
 type LogEntryAdditionalProp struct {
@@ -0,0 +1,90 @@
+package outputparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Benchmark struct {
+	Name       string // name of the benchmark without the GOMAXPROCS suffix
+	Procs      int
+	Iterations int
+	Metrics    []BenchmarkMetric
+}
+
+type BenchmarkMetric struct {
+	Value float64
+	Unit  string // eg. ns/op, B/op, allocs/op or a custom unit from b.ReportMetric
+}
+
+const (
+	UnitNsPerOp     = "ns/op"
+	UnitBytesPerOp  = "B/op"
+	UnitAllocsPerOp = "allocs/op"
+)
+
+// Metric finds the value of the metric with the given unit
+func (b Benchmark) Metric(unit string) (float64, bool) {
+	for _, metric := range b.Metrics {
+		if metric.Unit == unit {
+			return metric.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+// ParseBenchmark parses a benchmark result line in the format
+//
+//	BenchmarkFoo-8   1000   1234 ns/op   56 B/op   2 allocs/op
+func ParseBenchmark(line string) (Benchmark, bool) {
+	fields := strings.Fields(line)
+
+	// a name, the iterations and at least one value/unit pair
+	if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return Benchmark{}, false
+	}
+
+	iterations, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Benchmark{}, false
+	}
+
+	name, procs := splitProcs(fields[0])
+
+	benchmark := Benchmark{
+		Name:       name,
+		Procs:      procs,
+		Iterations: iterations,
+	}
+
+	for i := 2; i < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Benchmark{}, false
+		}
+
+		benchmark.Metrics = append(benchmark.Metrics, BenchmarkMetric{
+			Value: value,
+			Unit:  fields[i+1],
+		})
+	}
+
+	return benchmark, true
+}
+
+// splitProcs removes the "-8" GOMAXPROCS suffix which is added to the
+// benchmark name when GOMAXPROCS is greater than 1
+func splitProcs(name string) (string, int) {
+	idx := strings.LastIndex(name, "-")
+	if idx == -1 {
+		return name, 1
+	}
+
+	procs, err := strconv.Atoi(name[idx+1:])
+	if err != nil || procs < 1 {
+		return name, 1
+	}
+
+	return name[:idx], procs
+}
@@ -0,0 +1,124 @@
+package outputparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBenchmark(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		wantOk bool
+		want   Benchmark
+	}{
+		{
+			name:   "standard metrics",
+			line:   "BenchmarkFoo-8   \t    1000\t      1234 ns/op\t      56 B/op\t       2 allocs/op",
+			wantOk: true,
+			want: Benchmark{
+				Name:       "BenchmarkFoo",
+				Procs:      8,
+				Iterations: 1000,
+				Metrics: []BenchmarkMetric{
+					{Value: 1234, Unit: "ns/op"},
+					{Value: 56, Unit: "B/op"},
+					{Value: 2, Unit: "allocs/op"},
+				},
+			},
+		},
+		{
+			name:   "custom metric",
+			line:   "BenchmarkCustom/small-4    1000   2.227 ns/op   42.00 widgets/op",
+			wantOk: true,
+			want: Benchmark{
+				Name:       "BenchmarkCustom/small",
+				Procs:      4,
+				Iterations: 1000,
+				Metrics: []BenchmarkMetric{
+					{Value: 2.227, Unit: "ns/op"},
+					{Value: 42, Unit: "widgets/op"},
+				},
+			},
+		},
+		{
+			name:   "without procs suffix",
+			line:   "BenchmarkJoin   \t    1000\t        60.07 ns/op",
+			wantOk: true,
+			want: Benchmark{
+				Name:       "BenchmarkJoin",
+				Procs:      1,
+				Iterations: 1000,
+				Metrics: []BenchmarkMetric{
+					{Value: 60.07, Unit: "ns/op"},
+				},
+			},
+		},
+		{
+			name:   "sub benchmark with dash in name",
+			line:   "BenchmarkParse/size-large-16   10   99 ns/op",
+			wantOk: true,
+			want: Benchmark{
+				Name:       "BenchmarkParse/size-large",
+				Procs:      16,
+				Iterations: 10,
+				Metrics: []BenchmarkMetric{
+					{Value: 99, Unit: "ns/op"},
+				},
+			},
+		},
+		{
+			name:   "benchmark name only",
+			line:   "BenchmarkJoin",
+			wantOk: false,
+		},
+		{
+			name:   "regular log",
+			line:   "this is a log about Benchmarks",
+			wantOk: false,
+		},
+		{
+			name:   "invalid iterations",
+			line:   "BenchmarkFoo   many   12 ns/op",
+			wantOk: false,
+		},
+		{
+			name:   "invalid value",
+			line:   "BenchmarkFoo   100   fast ns/op",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseBenchmark(tt.line)
+
+			require.Equal(t, tt.wantOk, ok)
+			if tt.wantOk {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBenchmarkMetric(t *testing.T) {
+	b := Benchmark{
+		Metrics: []BenchmarkMetric{
+			{Value: 1234, Unit: UnitNsPerOp},
+			{Value: 42, Unit: "widgets/op"},
+		},
+	}
+
+	v, ok := b.Metric(UnitNsPerOp)
+	assert.True(t, ok)
+	assert.Equal(t, 1234.0, v)
+
+	v, ok = b.Metric("widgets/op")
+	assert.True(t, ok)
+	assert.Equal(t, 42.0, v)
+
+	_, ok = b.Metric(UnitAllocsPerOp)
+	assert.False(t, ok)
+}
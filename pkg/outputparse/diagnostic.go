@@ -0,0 +1,49 @@
+package outputparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is an error reported by the compiler
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int // zero when the compiler doesn't report one
+	Message string
+}
+
+// ParseDiagnostic parses an error written by the compiler while building a
+// package, with or without a column, eg.
+//
+//	./foo.go:12:5: undefined: Bar
+//	./foo.go:12: undefined: Bar
+func ParseDiagnostic(line string) (Diagnostic, bool) {
+	location, message, ok := strings.Cut(line, ": ")
+	if !ok || strings.ContainsAny(location, " \t") {
+		return Diagnostic{}, false
+	}
+
+	parts := strings.Split(location, ":")
+	if len(parts) < 2 || len(parts) > 3 || !strings.Contains(parts[0], ".") {
+		return Diagnostic{}, false
+	}
+
+	diagnostic := Diagnostic{
+		File:    parts[0],
+		Message: message,
+	}
+
+	var err error
+	if diagnostic.Line, err = strconv.Atoi(parts[1]); err != nil {
+		return Diagnostic{}, false
+	}
+
+	if len(parts) == 3 {
+		if diagnostic.Column, err = strconv.Atoi(parts[2]); err != nil {
+			return Diagnostic{}, false
+		}
+	}
+
+	return diagnostic, true
+}
@@ -0,0 +1,44 @@
+package outputparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiagnostic(t *testing.T) {
+	testCases := []struct {
+		line string
+		want Diagnostic
+		ok   bool
+	}{
+		{
+			line: "./foo.go:12:5: undefined: Bar",
+			want: Diagnostic{File: "./foo.go", Line: 12, Column: 5, Message: "undefined: Bar"},
+			ok:   true,
+		},
+		{
+			line: "pkg/foo/foo_test.go:7:14: cannot use \"s\" (untyped string constant) as int value in variable declaration",
+			want: Diagnostic{File: "pkg/foo/foo_test.go", Line: 7, Column: 14, Message: "cannot use \"s\" (untyped string constant) as int value in variable declaration"},
+			ok:   true,
+		},
+		{
+			line: "foo.go:4: syntax error: unexpected EOF",
+			want: Diagnostic{File: "foo.go", Line: 4, Message: "syntax error: unexpected EOF"},
+			ok:   true,
+		},
+		{line: "# example.com/foo"},
+		{line: "too many errors"},
+		{line: "    foo_test.go:12: expected 3"},
+		{line: "foo.go:abc: not a line"},
+		{line: "note: module requires Go 1.25"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			got, ok := ParseDiagnostic(tc.line)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package outputparse
+
+import (
+	"strings"
+)
+
+type DiffLineKind int
+
+const (
+	DiffContext DiffLineKind = iota
+	DiffRemoved
+	DiffAdded
+	DiffHunk   // eg. @@ -1,3 +1,3 @@
+	DiffHeader // the line introducing the diff, eg. --- a/foo.go or mismatch (-want +got):
+)
+
+// DiffBlock is a range of lines which form a diff
+type DiffBlock struct {
+	Start int
+	End   int // exclusive
+
+	// Indent is the column of the +/- markers
+	Indent int
+}
+
+type DiffLine struct {
+	Kind DiffLineKind
+
+	// Text is the line without its indentation and marker
+	Text string
+}
+
+// FindDiffBlocks finds the unified diffs and go-cmp diffs within the lines.
+// A diff is only detected when introduced by a header, otherwise any line
+// starting with a - would be mistaken for one
+//
+//	mismatch (-want +got):
+//	  example.user{
+//	- 	Age: 3,
+//	+ 	Age: 4,
+//	  }
+//
+//	--- a/foo.txt
+//	+++ b/foo.txt
+//	@@ -1 +1 @@
+//	-foo
+//	+bar
+func FindDiffBlocks(lines []string) []DiffBlock {
+	var blocks []DiffBlock
+
+	for i := 0; i < len(lines); i++ {
+		bodyStart := -1
+
+		switch {
+		case isCmpHeader(lines[i]):
+			bodyStart = i + 1
+		case isUnifiedHeader(lines, i):
+			bodyStart = i
+		default:
+			continue
+		}
+
+		indent, ok := diffIndent(lines[bodyStart:])
+		if !ok {
+			continue
+		}
+
+		end := bodyStart
+		for end < len(lines) && isDiffLine(lines[end], indent) {
+			end++
+		}
+
+		blocks = append(blocks, DiffBlock{Start: i, End: end, Indent: indent})
+		i = end - 1
+	}
+
+	return blocks
+}
+
+// ParseDiffLine classifies a line of a diff block by its marker
+func ParseDiffLine(line string, indent int) DiffLine {
+	line = normalizeSpaces(line)
+	trimmed := strings.TrimSpace(line)
+
+	switch {
+	case strings.HasPrefix(trimmed, "--- ") || strings.HasPrefix(trimmed, "+++ ") || isCmpHeader(line):
+		return DiffLine{Kind: DiffHeader, Text: trimmed}
+	case strings.HasPrefix(trimmed, "@@ "):
+		return DiffLine{Kind: DiffHunk, Text: trimmed}
+	}
+
+	if len(line) <= indent {
+		return DiffLine{Kind: DiffContext}
+	}
+
+	text := line[indent+1:]
+	switch line[indent] {
+	case '-':
+		return DiffLine{Kind: DiffRemoved, Text: text}
+	case '+':
+		return DiffLine{Kind: DiffAdded, Text: text}
+	default:
+		return DiffLine{Kind: DiffContext, Text: text}
+	}
+}
+
+// isCmpHeader checks for the message introducing a go-cmp diff, which by
+// convention ends with the legend of the markers, eg. (-want +got):
+func isCmpHeader(line string) bool {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(line), ":")
+	if !strings.HasSuffix(trimmed, ")") {
+		return false
+	}
+
+	idx := strings.LastIndex(trimmed, "(-")
+	if idx < 0 {
+		return false
+	}
+
+	legend := strings.Fields(trimmed[idx+1 : len(trimmed)-1])
+
+	return len(legend) == 2 && strings.HasPrefix(legend[1], "+")
+}
+
+func isUnifiedHeader(lines []string, i int) bool {
+	trimmed := strings.TrimSpace(lines[i])
+
+	if strings.HasPrefix(trimmed, "@@ -") {
+		return true
+	}
+
+	return strings.HasPrefix(trimmed, "--- ") &&
+		i+1 < len(lines) &&
+		strings.HasPrefix(strings.TrimSpace(lines[i+1]), "+++ ")
+}
+
+// diffIndent finds the column of the markers from the first removed or added
+// line. Context lines are indented further so can't be used
+func diffIndent(lines []string) (int, bool) {
+	for _, line := range lines {
+		line = normalizeSpaces(line)
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" {
+			continue
+		}
+
+		switch trimmed[0] {
+		case '-', '+':
+			return len(line) - len(trimmed), true
+		case '@':
+			continue
+		}
+
+		// a line which isn't indented past the context can't be part of the diff
+		if len(line)-len(trimmed) == 0 {
+			return 0, false
+		}
+	}
+
+	return 0, false
+}
+
+func isDiffLine(line string, indent int) bool {
+	line = normalizeSpaces(line)
+
+	if strings.TrimSpace(line) == "" {
+		return len(line) > 0
+	}
+
+	if len(line) <= indent || strings.TrimSpace(line[:indent]) != "" {
+		return false
+	}
+
+	switch line[indent] {
+	case ' ', '\t', '-', '+', '@', '\\':
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeSpaces replaces the non-breaking spaces which go-cmp randomly uses
+// in place of spaces to discourage comparing its output
+func normalizeSpaces(line string) string {
+	return strings.ReplaceAll(line, "\u00a0", " ")
+}
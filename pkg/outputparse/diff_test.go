@@ -0,0 +1,84 @@
+package outputparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDiffBlocks_Cmp(t *testing.T) {
+	lines := []string{
+		"    foo_test.go:10: starting",
+		"    foo_test.go:12: mismatch (-want +got):",
+		"          example.user{",
+		"          \tName: \"bob\",",
+		"        - \tAge:  3,",
+		"        + \tAge:  4,",
+		"          }",
+		"    foo_test.go:14: done",
+	}
+
+	assert.Equal(t, []DiffBlock{{Start: 1, End: 7, Indent: 8}}, FindDiffBlocks(lines))
+}
+
+func TestFindDiffBlocks_CmpNonBreakingSpaces(t *testing.T) {
+	lines := []string{
+		"mismatch (-want +got):",
+		"\u00a0 []string{",
+		"-\u00a0\t\"a\",",
+		"+\u00a0\t\"b\",",
+		" \u00a0}",
+	}
+
+	assert.Equal(t, []DiffBlock{{Start: 0, End: 5, Indent: 0}}, FindDiffBlocks(lines))
+	assert.Equal(t, DiffLine{Kind: DiffAdded, Text: " \t\"b\","}, ParseDiffLine(lines[3], 0))
+}
+
+func TestFindDiffBlocks_Unified(t *testing.T) {
+	lines := []string{
+		"    foo_test.go:20: golden file differs",
+		"        --- a/testdata/out.golden",
+		"        +++ b/testdata/out.golden",
+		"        @@ -1,2 +1,2 @@",
+		"         first",
+		"        -second",
+		"        +2nd",
+		"    foo_test.go:21: done",
+	}
+
+	assert.Equal(t, []DiffBlock{{Start: 1, End: 7, Indent: 8}}, FindDiffBlocks(lines))
+}
+
+func TestFindDiffBlocks_NoHeader(t *testing.T) {
+	lines := []string{
+		"- item one",
+		"- item two",
+		"+ not a diff",
+		"mismatch (-want +got):",
+		"no diff follows",
+	}
+
+	assert.Empty(t, FindDiffBlocks(lines))
+}
+
+func TestParseDiffLine(t *testing.T) {
+	testCases := []struct {
+		line string
+		want DiffLine
+	}{
+		{line: "    --- a/out.golden", want: DiffLine{Kind: DiffHeader, Text: "--- a/out.golden"}},
+		{line: "    +++ b/out.golden", want: DiffLine{Kind: DiffHeader, Text: "+++ b/out.golden"}},
+		{line: "    mismatch (-want +got):", want: DiffLine{Kind: DiffHeader, Text: "mismatch (-want +got):"}},
+		{line: "    @@ -1,2 +1,2 @@", want: DiffLine{Kind: DiffHunk, Text: "@@ -1,2 +1,2 @@"}},
+		{line: "    -second", want: DiffLine{Kind: DiffRemoved, Text: "second"}},
+		{line: "    +2nd", want: DiffLine{Kind: DiffAdded, Text: "2nd"}},
+		{line: "     first", want: DiffLine{Kind: DiffContext, Text: "first"}},
+		{line: "    ", want: DiffLine{Kind: DiffContext}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			assert.Equal(t, tc.want, ParseDiffLine(tc.line, 4))
+		})
+	}
+}
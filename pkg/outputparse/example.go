@@ -0,0 +1,55 @@
+package outputparse
+
+import (
+	"slices"
+	"strings"
+)
+
+// ExampleFailure is the output of an example which didn't match its output
+// comment
+type ExampleFailure struct {
+	Got  []string
+	Want []string
+
+	// Unordered is set for // Unordered output: comments, where the lines
+	// may be printed in any order
+	Unordered bool
+}
+
+// ParseExampleFailure parses the output printed by the testing package when
+// an example fails
+//
+//	got:
+//	a
+//	b
+//	want:
+//	a
+//	c
+func ParseExampleFailure(lines []string) (ExampleFailure, bool) {
+	if len(lines) == 0 || lines[0] != "got:" {
+		return ExampleFailure{}, false
+	}
+
+	wantIdx := slices.IndexFunc(lines, func(line string) bool {
+		return line == "want:" || line == "want (unordered):"
+	})
+	if wantIdx < 0 {
+		return ExampleFailure{}, false
+	}
+
+	return ExampleFailure{
+		Got:       trimBlankLines(lines[1:wantIdx]),
+		Want:      trimBlankLines(lines[wantIdx+1:]),
+		Unordered: lines[wantIdx] == "want (unordered):",
+	}, true
+}
+
+// trimBlankLines removes the trailing blank lines, which the testing package
+// prints after unordered output
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
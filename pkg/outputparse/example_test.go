@@ -0,0 +1,41 @@
+package outputparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExampleFailure(t *testing.T) {
+	lines := []string{"got:", "a", "b", "c", "want:", "a", "x", "c"}
+
+	failure, ok := ParseExampleFailure(lines)
+	require.True(t, ok)
+
+	assert.Equal(t, ExampleFailure{
+		Got:  []string{"a", "b", "c"},
+		Want: []string{"a", "x", "c"},
+	}, failure)
+}
+
+func TestParseExampleFailure_Unordered(t *testing.T) {
+	lines := []string{"got:", "1", "2", "", "want (unordered):", "3", "1", ""}
+
+	failure, ok := ParseExampleFailure(lines)
+	require.True(t, ok)
+
+	assert.Equal(t, ExampleFailure{
+		Got:       []string{"1", "2"},
+		Want:      []string{"3", "1"},
+		Unordered: true,
+	}, failure)
+}
+
+func TestParseExampleFailure_NotExample(t *testing.T) {
+	_, ok := ParseExampleFailure([]string{"foo_test.go:10: got: 3"})
+	assert.False(t, ok)
+
+	_, ok = ParseExampleFailure([]string{"got:", "a"})
+	assert.False(t, ok)
+}
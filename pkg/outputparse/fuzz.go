@@ -0,0 +1,70 @@
+package outputparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type FuzzProgress struct {
+	Elapsed time.Duration
+
+	Execs            int64
+	ExecsPerSec      int64
+	NewInteresting   int
+	TotalInteresting int
+
+	// Phase describes what the fuzzer is doing when it isn't reporting execs,
+	// eg. gathering baseline coverage or minimizing a failing input
+	Phase string
+}
+
+// ParseFuzzProgress parses a progress line written by `go test -fuzz` in the formats
+//
+//	fuzz: elapsed: 3s, execs: 102343 (34074/sec), new interesting: 0 (total: 2)
+//	fuzz: elapsed: 0s, gathering baseline coverage: 0/2 completed
+//	fuzz: minimizing 31-byte failing input file
+func ParseFuzzProgress(line string) (FuzzProgress, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), "fuzz: ")
+	if !ok {
+		return FuzzProgress{}, false
+	}
+
+	var progress FuzzProgress
+
+	if elapsedStatus, ok := strings.CutPrefix(rest, "elapsed: "); ok {
+		elapsed, status, _ := strings.Cut(elapsedStatus, ", ")
+
+		d, err := time.ParseDuration(elapsed)
+		if err != nil {
+			return FuzzProgress{}, false
+		}
+
+		progress.Elapsed = d
+		rest = status
+	}
+
+	_, err := fmt.Sscanf(
+		rest,
+		"execs: %d (%d/sec), new interesting: %d (total: %d)",
+		&progress.Execs, &progress.ExecsPerSec, &progress.NewInteresting, &progress.TotalInteresting,
+	)
+	if err != nil {
+		progress.Phase = rest
+	}
+
+	return progress, true
+}
+
+// ParseFuzzFailingInput parses the path of the corpus file written when a fuzz
+// target fails, eg.
+//
+//	Failing input written to testdata/fuzz/FuzzFoo/1de061fa29cfbb3d
+func ParseFuzzFailingInput(line string) (string, bool) {
+	path, ok := strings.CutPrefix(strings.TrimSpace(line), "Failing input written to ")
+	if !ok || path == "" {
+		return "", false
+	}
+
+	return path, true
+}
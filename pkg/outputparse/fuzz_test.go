@@ -0,0 +1,84 @@
+package outputparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFuzzProgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   FuzzProgress
+		wantOk bool
+	}{
+		{
+			name: "execs",
+			line: "fuzz: elapsed: 3s, execs: 102343 (34074/sec), new interesting: 1 (total: 2)",
+			want: FuzzProgress{
+				Elapsed:          3 * time.Second,
+				Execs:            102343,
+				ExecsPerSec:      34074,
+				NewInteresting:   1,
+				TotalInteresting: 2,
+			},
+			wantOk: true,
+		},
+		{
+			name: "minutes elapsed",
+			line: "fuzz: elapsed: 1m3s, execs: 5 (1/sec), new interesting: 0 (total: 1)\n",
+			want: FuzzProgress{
+				Elapsed:          time.Minute + 3*time.Second,
+				Execs:            5,
+				ExecsPerSec:      1,
+				TotalInteresting: 1,
+			},
+			wantOk: true,
+		},
+		{
+			name: "baseline coverage",
+			line: "fuzz: elapsed: 0s, gathering baseline coverage: 2/2 completed, now fuzzing with 2 workers",
+			want: FuzzProgress{
+				Phase: "gathering baseline coverage: 2/2 completed, now fuzzing with 2 workers",
+			},
+			wantOk: true,
+		},
+		{
+			name: "minimizing without elapsed",
+			line: "fuzz: minimizing 31-byte failing input file",
+			want: FuzzProgress{
+				Phase: "minimizing 31-byte failing input file",
+			},
+			wantOk: true,
+		},
+		{
+			name:   "invalid elapsed",
+			line:   "fuzz: elapsed: soon, execs: 1 (1/sec), new interesting: 0 (total: 0)",
+			wantOk: false,
+		},
+		{
+			name:   "regular log",
+			line:   "fuzzing is fun",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseFuzzProgress(tt.line)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFuzzFailingInput(t *testing.T) {
+	path, ok := ParseFuzzFailingInput("    Failing input written to testdata/fuzz/FuzzReverse/1de061fa29cfbb3d")
+	assert.True(t, ok)
+	assert.Equal(t, "testdata/fuzz/FuzzReverse/1de061fa29cfbb3d", path)
+
+	_, ok = ParseFuzzFailingInput("    To re-run:")
+	assert.False(t, ok)
+}
@@ -0,0 +1,26 @@
+package outputparse
+
+import (
+	"strings"
+)
+
+// ParseLogLocation parses the location printed by the testing package at the
+// start of t.Log and t.Error output, eg.
+//
+//	foo_test.go:42: expected 3
+func ParseLogLocation(line string) (string, int, bool) {
+	location := strings.TrimSpace(line)
+
+	if idx := strings.Index(location, ": "); idx >= 0 {
+		location = location[:idx]
+	} else {
+		location = strings.TrimSuffix(location, ":")
+	}
+
+	file, lineNo := parseLocation(location)
+	if lineNo == 0 || !strings.HasSuffix(file, ".go") || strings.ContainsAny(file, " \t") {
+		return "", 0, false
+	}
+
+	return file, lineNo, true
+}
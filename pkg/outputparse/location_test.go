@@ -0,0 +1,33 @@
+package outputparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogLocation(t *testing.T) {
+	testCases := []struct {
+		line   string
+		file   string
+		lineNo int
+		ok     bool
+	}{
+		{line: "    foo_test.go:42: expected 3", file: "foo_test.go", lineNo: 42, ok: true},
+		{line: "        foo_test.go:16: ", file: "foo_test.go", lineNo: 16, ok: true},
+		{line: "foo_test.go:7:", file: "foo_test.go", lineNo: 7, ok: true},
+		{line: "    expected 3: got 4"},
+		{line: "    config.yaml:12: invalid"},
+		{line: "    foo_test.go: missing line"},
+		{line: "time=2025-10-05T09:52:58.046+11:00 level=INFO msg=hello"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			file, lineNo, ok := ParseLogLocation(tc.line)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.file, file)
+			assert.Equal(t, tc.lineNo, lineNo)
+		})
+	}
+}
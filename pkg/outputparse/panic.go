@@ -0,0 +1,259 @@
+package outputparse
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const timeoutPrefix = "test timed out after "
+
+type Panic struct {
+	// Message is the value passed to panic, along with any extra lines such
+	// as the signal of a nil pointer dereference
+	Message string
+
+	// RunningTests are listed when the panic is caused by the -timeout flag
+	RunningTests []RunningTest
+
+	Goroutines []Goroutine
+}
+
+type RunningTest struct {
+	Name    string
+	Elapsed time.Duration
+}
+
+type Goroutine struct {
+	ID    int
+	State string // eg. running, chan receive, 2 minutes
+
+	Frames []StackFrame
+}
+
+type StackFrame struct {
+	Function string
+	File     string
+	Line     int
+
+	// CreatedBy is set for the frame which started the goroutine
+	CreatedBy bool
+}
+
+// IsTimeout checks if the panic was caused by the test binary exceeding -timeout
+func (p Panic) IsTimeout() bool {
+	return strings.HasPrefix(p.Message, timeoutPrefix)
+}
+
+// IsPanic checks if the line starts the output of a panic
+func IsPanic(line string) bool {
+	return strings.HasPrefix(line, "panic: ")
+}
+
+// ParsePanic parses the output of a panic, starting from the `panic:` line
+// and followed by the goroutine stacks
+//
+//	panic: boom
+//
+//	goroutine 9 [running]:
+//	example.TestFoo.func1()
+//		/src/example/foo_test.go:16 +0x25
+//	created by example.TestFoo in goroutine 8
+//		/src/example/foo_test.go:15 +0x1f
+//
+// When the test binary times out, the panic also lists the tests which were running
+//
+//	panic: test timed out after 10m0s
+//		running tests:
+//			TestFoo (10m0s)
+func ParsePanic(lines []string) (Panic, bool) {
+	if len(lines) == 0 || !IsPanic(lines[0]) {
+		return Panic{}, false
+	}
+
+	var (
+		p       Panic
+		message []string
+		current *Goroutine
+	)
+
+	inMessage := true
+	inRunningTests := false
+
+	for i, line := range lines {
+		if goroutine, ok := parseGoroutineHeader(line); ok {
+			inMessage = false
+			p.Goroutines = append(p.Goroutines, goroutine)
+			current = &p.Goroutines[len(p.Goroutines)-1]
+			continue
+		}
+
+		if inMessage {
+			if i == 0 {
+				line = strings.TrimPrefix(line, "panic: ")
+			}
+
+			if strings.TrimSpace(line) == "" {
+				inMessage = false
+				continue
+			}
+
+			if strings.TrimSpace(line) == "running tests:" {
+				inRunningTests = true
+				continue
+			}
+
+			if inRunningTests {
+				if test, ok := parseRunningTest(line); ok {
+					p.RunningTests = append(p.RunningTests, test)
+				}
+				continue
+			}
+
+			message = append(message, line)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if location, ok := strings.CutPrefix(line, "\t"); ok {
+			// the location belongs to the function on the previous line
+			if len(current.Frames) > 0 {
+				frame := &current.Frames[len(current.Frames)-1]
+				frame.File, frame.Line = parseLocation(location)
+			}
+			continue
+		}
+
+		if function, ok := strings.CutPrefix(line, "created by "); ok {
+			function, _, _ = strings.Cut(function, " in goroutine ")
+			current.Frames = append(current.Frames, StackFrame{Function: function, CreatedBy: true})
+			continue
+		}
+
+		// the arguments are the last parentheses, the function itself can
+		// contain parentheses such as testing.(*T).Run
+		if idx := strings.LastIndex(line, "("); idx > 0 && strings.HasSuffix(line, ")") {
+			current.Frames = append(current.Frames, StackFrame{Function: line[:idx]})
+		}
+	}
+
+	p.Message = strings.Join(message, "\n")
+
+	return p, true
+}
+
+// parseGoroutineHeader parses the line which starts each goroutine stack, eg.
+//
+//	goroutine 9 [chan receive, 2 minutes]:
+func parseGoroutineHeader(line string) (Goroutine, bool) {
+	rest, ok := strings.CutPrefix(line, "goroutine ")
+	if !ok || !strings.HasSuffix(rest, "]:") {
+		return Goroutine{}, false
+	}
+
+	id, state, ok := strings.Cut(strings.TrimSuffix(rest, "]:"), " [")
+	if !ok {
+		return Goroutine{}, false
+	}
+
+	goroutineID, err := strconv.Atoi(id)
+	if err != nil {
+		return Goroutine{}, false
+	}
+
+	return Goroutine{
+		ID:    goroutineID,
+		State: state,
+	}, true
+}
+
+// parseLocation parses the file and line of a frame, eg.
+//
+//	/src/example/foo_test.go:16 +0x25
+func parseLocation(location string) (string, int) {
+	location, _, _ = strings.Cut(location, " +0x")
+
+	idx := strings.LastIndex(location, ":")
+	if idx < 0 {
+		return location, 0
+	}
+
+	line, err := strconv.Atoi(location[idx+1:])
+	if err != nil {
+		return location, 0
+	}
+
+	return location[:idx], line
+}
+
+// parseRunningTest parses a test listed by a timeout panic, eg.
+//
+//	TestFoo/bar (10m0s)
+func parseRunningTest(line string) (RunningTest, bool) {
+	name, elapsed, ok := strings.Cut(strings.TrimSpace(line), " (")
+	if !ok || !strings.HasSuffix(elapsed, ")") {
+		return RunningTest{}, false
+	}
+
+	d, err := time.ParseDuration(strings.TrimSuffix(elapsed, ")"))
+	if err != nil {
+		return RunningTest{}, false
+	}
+
+	return RunningTest{Name: name, Elapsed: d}, true
+}
+
+// GoroutineGroup is a set of goroutines with identical stacks
+type GoroutineGroup struct {
+	IDs    []int
+	State  string
+	Frames []StackFrame
+}
+
+// GroupGoroutines groups goroutines which are in the same state with the same
+// stack, ignoring how long they have been waiting. Larger groups are first,
+// which is usually where a deadlock or leak is
+func GroupGoroutines(goroutines []Goroutine) []GoroutineGroup {
+	var groups []GoroutineGroup
+
+	for _, goroutine := range goroutines {
+		state := stateWithoutWait(goroutine.State)
+
+		idx := slices.IndexFunc(groups, func(g GoroutineGroup) bool {
+			return g.State == state && slices.Equal(g.Frames, goroutine.Frames)
+		})
+
+		if idx >= 0 {
+			groups[idx].IDs = append(groups[idx].IDs, goroutine.ID)
+			continue
+		}
+
+		groups = append(groups, GoroutineGroup{
+			IDs:    []int{goroutine.ID},
+			State:  state,
+			Frames: goroutine.Frames,
+		})
+	}
+
+	slices.SortStableFunc(groups, func(a, b GoroutineGroup) int {
+		return len(b.IDs) - len(a.IDs)
+	})
+
+	return groups
+}
+
+// stateWithoutWait removes the wait duration from the goroutine state, eg.
+// "chan receive, 2 minutes" becomes "chan receive"
+func stateWithoutWait(state string) string {
+	parts := strings.Split(state, ", ")
+
+	parts = slices.DeleteFunc(parts, func(part string) bool {
+		return strings.HasSuffix(part, " minutes") || strings.HasSuffix(part, " minute")
+	})
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,127 @@
+package outputparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePanic(t *testing.T) {
+	lines := []string{
+		"panic: runtime error: invalid memory address or nil pointer dereference [recovered]",
+		"[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4f2b6a]",
+		"",
+		"goroutine 10 [running]:",
+		"testing.tRunner.func1.2({0x6b6f90, 0x6eef80})",
+		"\t/usr/local/go/src/testing/testing.go:2123 +0x232",
+		"example.helper(...)",
+		"\t/src/example/foo_test.go:8",
+		"example.TestFoo.func1(0x103647268908?)",
+		"\t/src/example/foo_test.go:22 +0x33",
+		"testing.(*T).Run(0xc000007040, {0x5a1b2c, 0x3}, 0x5b1c28)",
+		"\t/usr/local/go/src/testing/testing.go:2258 +0x4d4",
+		"created by testing.(*T).Run in goroutine 9",
+		"\t/usr/local/go/src/testing/testing.go:2258 +0x4d4",
+		"",
+		"goroutine 1 [chan receive, 2 minutes]:",
+		"main.main()",
+		"\t_testmain.go:47 +0x5b",
+		"exit status 2",
+	}
+
+	p, ok := ParsePanic(lines)
+	require.True(t, ok)
+
+	assert.Equal(t, "runtime error: invalid memory address or nil pointer dereference [recovered]\n[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4f2b6a]", p.Message)
+	require.Len(t, p.Goroutines, 2)
+
+	assert.Equal(t, 10, p.Goroutines[0].ID)
+	assert.Equal(t, "running", p.Goroutines[0].State)
+	assert.Equal(t, []StackFrame{
+		{Function: "testing.tRunner.func1.2", File: "/usr/local/go/src/testing/testing.go", Line: 2123},
+		{Function: "example.helper", File: "/src/example/foo_test.go", Line: 8},
+		{Function: "example.TestFoo.func1", File: "/src/example/foo_test.go", Line: 22},
+		{Function: "testing.(*T).Run", File: "/usr/local/go/src/testing/testing.go", Line: 2258},
+		{Function: "testing.(*T).Run", File: "/usr/local/go/src/testing/testing.go", Line: 2258, CreatedBy: true},
+	}, p.Goroutines[0].Frames)
+
+	assert.Equal(t, 1, p.Goroutines[1].ID)
+	assert.Equal(t, "chan receive, 2 minutes", p.Goroutines[1].State)
+	assert.Equal(t, []StackFrame{
+		{Function: "main.main", File: "_testmain.go", Line: 47},
+	}, p.Goroutines[1].Frames)
+}
+
+func TestParsePanic_NotPanic(t *testing.T) {
+	_, ok := ParsePanic([]string{"    foo_test.go:12: panic: not really"})
+	assert.False(t, ok)
+
+	_, ok = ParsePanic(nil)
+	assert.False(t, ok)
+}
+
+func TestParseGoroutineHeader(t *testing.T) {
+	g, ok := parseGoroutineHeader("goroutine 42 [select]:")
+	assert.True(t, ok)
+	assert.Equal(t, Goroutine{ID: 42, State: "select"}, g)
+
+	_, ok = parseGoroutineHeader("goroutine forty [select]:")
+	assert.False(t, ok)
+
+	_, ok = parseGoroutineHeader("goroutines are great")
+	assert.False(t, ok)
+}
+
+func TestParsePanic_Timeout(t *testing.T) {
+	lines := []string{
+		"panic: test timed out after 10m0s",
+		"\trunning tests:",
+		"\t\tTestDeadlock (10m0s)",
+		"\t\tTestStuck/inner (9m58s)",
+		"",
+		"goroutine 13 [running]:",
+		"testing.(*M).startAlarm.func1()",
+		"\t/usr/local/go/src/testing/testing.go:2959 +0x34a",
+	}
+
+	p, ok := ParsePanic(lines)
+	require.True(t, ok)
+
+	assert.True(t, p.IsTimeout())
+	assert.Equal(t, "test timed out after 10m0s", p.Message)
+	assert.Equal(t, []RunningTest{
+		{Name: "TestDeadlock", Elapsed: 10 * time.Minute},
+		{Name: "TestStuck/inner", Elapsed: 9*time.Minute + 58*time.Second},
+	}, p.RunningTests)
+	assert.Len(t, p.Goroutines, 1)
+
+	p, ok = ParsePanic([]string{"panic: boom"})
+	require.True(t, ok)
+	assert.False(t, p.IsTimeout())
+}
+
+func TestGroupGoroutines(t *testing.T) {
+	lock := []StackFrame{
+		{Function: "sync.(*Mutex).Lock", File: "/usr/local/go/src/sync/mutex.go", Line: 46},
+		{Function: "example.TestDeadlock.func1", File: "/src/example/foo_test.go", Line: 15},
+	}
+	receive := []StackFrame{
+		{Function: "example.TestDeadlock", File: "/src/example/foo_test.go", Line: 18},
+	}
+
+	groups := GroupGoroutines([]Goroutine{
+		{ID: 8, State: "chan receive", Frames: receive},
+		{ID: 10, State: "sync.Mutex.Lock, 2 minutes", Frames: lock},
+		{ID: 11, State: "sync.Mutex.Lock", Frames: lock},
+		{ID: 12, State: "sync.Mutex.Lock, 1 minute", Frames: lock},
+		{ID: 13, State: "running", Frames: lock},
+	})
+
+	assert.Equal(t, []GoroutineGroup{
+		{IDs: []int{10, 11, 12}, State: "sync.Mutex.Lock", Frames: lock},
+		{IDs: []int{8}, State: "chan receive", Frames: receive},
+		{IDs: []int{13}, State: "running", Frames: lock},
+	}, groups)
+}
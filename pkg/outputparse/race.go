@@ -0,0 +1,145 @@
+package outputparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// RaceSeparator surrounds each report written by the race detector
+	RaceSeparator = "=================="
+	raceWarning   = "WARNING: DATA RACE"
+)
+
+type Race struct {
+	// Accesses are the conflicting memory accesses, the current access first
+	Accesses []RaceAccess
+
+	// Goroutines are where the goroutines involved in the race were created
+	Goroutines []RaceGoroutine
+}
+
+type RaceAccess struct {
+	Kind      string // eg. Read, Write, Previous write
+	Address   string
+	Goroutine string // eg. goroutine 9, main goroutine
+	Frames    []StackFrame
+}
+
+type RaceGoroutine struct {
+	ID     int
+	State  string // eg. running, finished
+	Frames []StackFrame
+}
+
+// IsRaceWarning checks if the line starts a data race report
+func IsRaceWarning(line string) bool {
+	return line == raceWarning
+}
+
+// ParseRace parses a data race report, without the surrounding separators
+//
+//	WARNING: DATA RACE
+//	Read at 0x00c0000182b8 by goroutine 9:
+//	  example.TestFoo.func1()
+//	      /src/example/foo_test.go:12 +0x33
+//
+//	Previous write at 0x00c0000182b8 by goroutine 10:
+//	  example.TestFoo.func2()
+//	      /src/example/foo_test.go:13 +0x45
+//
+//	Goroutine 9 (running) created at:
+//	  example.TestFoo()
+//	      /src/example/foo_test.go:12 +0x126
+func ParseRace(lines []string) (Race, bool) {
+	if len(lines) == 0 || !IsRaceWarning(lines[0]) {
+		return Race{}, false
+	}
+
+	var (
+		race   Race
+		frames *[]StackFrame
+	)
+
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			continue
+		case !strings.HasPrefix(line, " ") && strings.HasSuffix(line, ":"):
+			// a header for the frames which follow
+			frames = nil
+
+			if access, ok := parseRaceAccess(line); ok {
+				race.Accesses = append(race.Accesses, access)
+				frames = &race.Accesses[len(race.Accesses)-1].Frames
+			} else if goroutine, ok := parseRaceGoroutine(line); ok {
+				race.Goroutines = append(race.Goroutines, goroutine)
+				frames = &race.Goroutines[len(race.Goroutines)-1].Frames
+			}
+		case frames == nil:
+			continue
+		case strings.HasPrefix(line, "      "):
+			// the location belongs to the function on the previous line
+			if len(*frames) > 0 {
+				frame := &(*frames)[len(*frames)-1]
+				frame.File, frame.Line = parseLocation(trimmed)
+			}
+		case strings.HasSuffix(trimmed, ")"):
+			if idx := strings.LastIndex(trimmed, "("); idx > 0 {
+				*frames = append(*frames, StackFrame{Function: trimmed[:idx]})
+			}
+		}
+	}
+
+	return race, true
+}
+
+// parseRaceAccess parses the header of a memory access, eg.
+//
+//	Previous write at 0x00c0000182b8 by goroutine 10:
+func parseRaceAccess(line string) (RaceAccess, bool) {
+	kind, rest, ok := strings.Cut(strings.TrimSuffix(line, ":"), " at ")
+	if !ok {
+		return RaceAccess{}, false
+	}
+
+	address, goroutine, ok := strings.Cut(rest, " by ")
+	if !ok {
+		return RaceAccess{}, false
+	}
+
+	return RaceAccess{
+		Kind:      kind,
+		Address:   address,
+		Goroutine: goroutine,
+	}, true
+}
+
+// parseRaceGoroutine parses the header of where a goroutine was created, eg.
+//
+//	Goroutine 9 (running) created at:
+func parseRaceGoroutine(line string) (RaceGoroutine, bool) {
+	rest, ok := strings.CutPrefix(line, "Goroutine ")
+	if !ok {
+		return RaceGoroutine{}, false
+	}
+
+	rest, ok = strings.CutSuffix(rest, " created at:")
+	if !ok {
+		return RaceGoroutine{}, false
+	}
+
+	id, state, _ := strings.Cut(rest, " ")
+
+	goroutineID, err := strconv.Atoi(id)
+	if err != nil {
+		return RaceGoroutine{}, false
+	}
+
+	return RaceGoroutine{
+		ID:    goroutineID,
+		State: strings.Trim(state, "()"),
+	}, true
+}
@@ -0,0 +1,65 @@
+package outputparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRace(t *testing.T) {
+	lines := []string{
+		"WARNING: DATA RACE",
+		"Read at 0x00c0000182b8 by goroutine 9:",
+		"  example.TestFoo.func1()",
+		"      /src/example/foo_test.go:12 +0x33",
+		"",
+		"Previous write at 0x00c0000182b8 by main goroutine:",
+		"  example.TestFoo.func2()",
+		"      /src/example/foo_test.go:13 +0x45",
+		"",
+		"Goroutine 9 (running) created at:",
+		"  example.TestFoo()",
+		"      /src/example/foo_test.go:12 +0x126",
+		"  testing.(*T).Run.gowrap1()",
+		"      /usr/local/go/src/testing/testing.go:2258 +0x38",
+	}
+
+	race, ok := ParseRace(lines)
+	require.True(t, ok)
+
+	assert.Equal(t, []RaceAccess{
+		{
+			Kind:      "Read",
+			Address:   "0x00c0000182b8",
+			Goroutine: "goroutine 9",
+			Frames: []StackFrame{
+				{Function: "example.TestFoo.func1", File: "/src/example/foo_test.go", Line: 12},
+			},
+		},
+		{
+			Kind:      "Previous write",
+			Address:   "0x00c0000182b8",
+			Goroutine: "main goroutine",
+			Frames: []StackFrame{
+				{Function: "example.TestFoo.func2", File: "/src/example/foo_test.go", Line: 13},
+			},
+		},
+	}, race.Accesses)
+
+	assert.Equal(t, []RaceGoroutine{
+		{
+			ID:    9,
+			State: "running",
+			Frames: []StackFrame{
+				{Function: "example.TestFoo", File: "/src/example/foo_test.go", Line: 12},
+				{Function: "testing.(*T).Run.gowrap1", File: "/usr/local/go/src/testing/testing.go", Line: 2258},
+			},
+		},
+	}, race.Goroutines)
+}
+
+func TestParseRace_NotRace(t *testing.T) {
+	_, ok := ParseRace([]string{"WARNING: something else"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,131 @@
+package outputparse
+
+import (
+	"strings"
+)
+
+// AssertionFailure is a failed testify assertion
+type AssertionFailure struct {
+	// File and Line are where the assertion failed, taken from the error trace
+	// when present, otherwise from the location printed by the testing package
+	File string
+	Line int
+
+	// Trace is the error trace, the outermost call last
+	Trace []string
+
+	Message  string // eg. Not equal:
+	Expected string
+	Actual   string
+
+	// Diff is the unified diff between the expected and actual values,
+	// including the --- Expected and +++ Actual headers
+	Diff []string
+
+	// Details are the remaining lines of the error, such as the text of an
+	// unexpected error
+	Details []string
+
+	Test     string
+	Messages string
+}
+
+// ParseAssertionFailure parses the lines of a t.Error written by testify, the
+// first line being the location printed by the testing package
+//
+//	foo_test.go:16:
+//	    	Error Trace:	/src/example/foo_test.go:16
+//	    	Error:      	Not equal:
+//	    	            	expected: 3
+//	    	            	actual  : 4
+//	    	Test:       	TestFoo
+func ParseAssertionFailure(lines []string) (AssertionFailure, bool) {
+	if len(lines) < 2 {
+		return AssertionFailure{}, false
+	}
+
+	var (
+		labels []string
+		fields = make(map[string][]string)
+	)
+
+	for _, line := range lines[1:] {
+		// each field is indented with spaces by the testing package, then a tab
+		// by testify, which is deeper for subtests
+		rest, ok := strings.CutPrefix(strings.TrimLeft(line, " "), "\t")
+		if !ok {
+			return AssertionFailure{}, false
+		}
+
+		label, value, ok := strings.Cut(rest, "\t")
+		if !ok {
+			return AssertionFailure{}, false
+		}
+
+		// a blank label continues the previous field
+		if label = strings.TrimSpace(label); label != "" {
+			label = strings.TrimSuffix(label, ":")
+			labels = append(labels, label)
+		} else if len(labels) == 0 {
+			return AssertionFailure{}, false
+		}
+
+		current := labels[len(labels)-1]
+		fields[current] = append(fields[current], value)
+	}
+
+	errorLines, ok := fields["Error"]
+	if !ok {
+		return AssertionFailure{}, false
+	}
+
+	failure := AssertionFailure{
+		Message:  strings.TrimSpace(errorLines[0]),
+		Test:     strings.TrimSpace(strings.Join(fields["Test"], "")),
+		Messages: strings.Join(fields["Messages"], "\n"),
+	}
+
+	failure.File, failure.Line, _ = ParseLogLocation(lines[0])
+
+	for _, trace := range fields["Error Trace"] {
+		if trace = strings.TrimSpace(trace); trace != "" {
+			failure.Trace = append(failure.Trace, trace)
+		}
+	}
+
+	if len(failure.Trace) > 0 {
+		failure.File, failure.Line = parseLocation(failure.Trace[0])
+	}
+
+	failure.parseError(errorLines[1:])
+
+	return failure, true
+}
+
+// parseError splits the remaining lines of the error into the expected and
+// actual values, the diff and any other details
+func (af *AssertionFailure) parseError(lines []string) {
+	var value *string
+
+	for i, line := range lines {
+		switch {
+		case line == "Diff:":
+			af.Diff = lines[i+1:]
+			return
+		case strings.HasPrefix(line, "expected: "):
+			af.Expected = strings.TrimPrefix(line, "expected: ")
+			value = &af.Expected
+		case strings.HasPrefix(line, "actual  : "):
+			af.Actual = strings.TrimPrefix(line, "actual  : ")
+			value = &af.Actual
+		case line == "":
+			// the values are separated from the diff by a blank line
+			value = nil
+		case value != nil:
+			// values which span multiple lines
+			*value += "\n" + line
+		default:
+			af.Details = append(af.Details, line)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package outputparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAssertionFailure(t *testing.T) {
+	lines := []string{
+		"    foo_test.go:16: ",
+		"        \tError Trace:\t/src/example/foo_test.go:16",
+		"        \t            \t\t/src/example/helpers_test.go:8",
+		"        \tError:      \tNot equal: ",
+		"        \t            \texpected: example.user{Name:\"bob\", Age:3}",
+		"        \t            \tactual  : example.user{Name:\"bob\", Age:4}",
+		"        \t            \t",
+		"        \t            \tDiff:",
+		"        \t            \t--- Expected",
+		"        \t            \t+++ Actual",
+		"        \t            \t@@ -2,3 +2,3 @@",
+		"        \t            \t  Name: (string) (len=3) \"bob\",",
+		"        \t            \t- Age: (int) 3",
+		"        \t            \t+ Age: (int) 4",
+		"        \t            \t }",
+		"        \tTest:       \tTestFoo",
+		"        \tMessages:   \tusers should match",
+	}
+
+	failure, ok := ParseAssertionFailure(lines)
+	require.True(t, ok)
+
+	assert.Equal(t, AssertionFailure{
+		File: "/src/example/foo_test.go",
+		Line: 16,
+		Trace: []string{
+			"/src/example/foo_test.go:16",
+			"/src/example/helpers_test.go:8",
+		},
+		Message:  "Not equal:",
+		Expected: "example.user{Name:\"bob\", Age:3}",
+		Actual:   "example.user{Name:\"bob\", Age:4}",
+		Diff: []string{
+			"--- Expected",
+			"+++ Actual",
+			"@@ -2,3 +2,3 @@",
+			"  Name: (string) (len=3) \"bob\",",
+			"- Age: (int) 3",
+			"+ Age: (int) 4",
+			" }",
+		},
+		Test:     "TestFoo",
+		Messages: "users should match",
+	}, failure)
+}
+
+func TestParseAssertionFailure_Details(t *testing.T) {
+	lines := []string{
+		"        foo_test.go:20: ",
+		"            \tError Trace:\t/src/example/foo_test.go:20",
+		"            \tError:      \tReceived unexpected error:",
+		"            \t            \tsomething broke",
+		"            \t            \tacross lines",
+		"            \tTest:       \tTestFoo/bar",
+	}
+
+	failure, ok := ParseAssertionFailure(lines)
+	require.True(t, ok)
+
+	assert.Equal(t, "Received unexpected error:", failure.Message)
+	assert.Equal(t, []string{"something broke", "across lines"}, failure.Details)
+	assert.Equal(t, "TestFoo/bar", failure.Test)
+	assert.Empty(t, failure.Expected)
+	assert.Empty(t, failure.Diff)
+}
+
+func TestParseAssertionFailure_MultilineValues(t *testing.T) {
+	lines := []string{
+		"    foo_test.go:12: ",
+		"        \tError:      \tNot equal: ",
+		"        \t            \texpected: \"a\\nb\"",
+		"        \t            \tcontinued",
+		"        \t            \tactual  : \"a\\nc\"",
+	}
+
+	failure, ok := ParseAssertionFailure(lines)
+	require.True(t, ok)
+
+	// without an error trace, the location is taken from the testing package
+	assert.Equal(t, "foo_test.go", failure.File)
+	assert.Equal(t, 12, failure.Line)
+	assert.Equal(t, "\"a\\nb\"\ncontinued", failure.Expected)
+	assert.Equal(t, "\"a\\nc\"", failure.Actual)
+}
+
+func TestParseAssertionFailure_NotTestify(t *testing.T) {
+	testCases := []struct {
+		name  string
+		lines []string
+	}{
+		{name: "single line", lines: []string{"    foo_test.go:12: want 3, got 4"}},
+		{name: "plain continuation", lines: []string{"    foo_test.go:12: mismatch", "        want 3", "        got 4"}},
+		{name: "no error field", lines: []string{"    foo_test.go:12: ", "        \tTest:       \tTestFoo"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, ok := ParseAssertionFailure(tc.lines)
+			assert.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package attrs
+
+import (
+	"testing"
+)
+
+func TestOwnedByPayments(t *testing.T) {
+	t.Attr("owner", "payments")
+	t.Attr("ticket", "PAY-123")
+
+	t.Log("this test has attributes")
+}
+
+func TestOwnedBySearch(t *testing.T) {
+	t.Attr("owner", "search")
+
+	t.Log("this test has an attribute")
+}
@@ -0,0 +1,29 @@
+package fuzz
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func reverse(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// the seed corpus passes, but fuzzing quickly finds that multi-byte runes
+// are broken by reversing the bytes
+func FuzzReverse(f *testing.F) {
+	f.Add("hello")
+	f.Add("sift")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		rev := reverse(s)
+
+		if utf8.ValidString(s) && !utf8.ValidString(rev) {
+			t.Errorf("reverse produced invalid utf-8 string %q", rev)
+		}
+	})
+}